@@ -14,9 +14,9 @@
 package api
 
 import (
-	"log"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
@@ -129,13 +129,13 @@ func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (a *Async) Validate() {
+func (a *Async) Validate(yamlPath string, diags *google.Diagnostics) {
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
-			log.Fatalf("Missing `Operation` for OpAsync")
+			diags.Errorf(yamlPath, "", "Missing `Operation` for OpAsync")
 		} else {
 			if a.Operation.BaseUrl != "" && a.Operation.FullUrl != "" {
-				log.Fatalf("`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+				diags.Errorf(yamlPath, "", "`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
 			}
 		}
 	}
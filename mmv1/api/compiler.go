@@ -15,22 +15,25 @@ package api
 
 import (
 	"bytes"
-	"log"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}, overrideDir string) {
+// Reads the YAML file at yamlPath into obj. Problems are reported to diags
+// rather than aborting the run; the returned error tells the caller that obj
+// may be incomplete and should not be processed further.
+func Compile(yamlPath string, obj interface{}, overrideDir string, diags *google.Diagnostics) error {
 	objYaml, err := os.ReadFile(yamlPath)
 	if err != nil {
-		log.Fatalf("Cannot open the file: %v", objYaml)
+		diags.Errorf(yamlPath, "", "Cannot open the file: %v", err)
+		return err
 	}
 
 	if overrideDir != "" {
 		objYaml = bytes.ReplaceAll(objYaml, []byte("{{override_path}}"), []byte(overrideDir))
 	}
 
-	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	yamlValidator := google.YamlValidator{Diagnostics: diags}
+	return yamlValidator.Parse(objYaml, obj, yamlPath)
 }
@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"golang.org/x/exp/slices"
+)
+
+// Information about the ephemeral resource generated for this resource.
+// Ephemeral resources call a read or generate method of the API when they are
+// opened, and return its response without storing it in the state or plan,
+// eg: to access a secret payload or to generate a short-lived certificate.
+//
+// The parameters of `url` are string arguments of the ephemeral resource.
+// `project`, `region` and `zone` are optional and default to the provider
+// configuration.
+type Ephemeral struct {
+	// boolean of if the ephemeral resource should be generated
+	Exclude bool
+
+	// [Optional] The version of the ephemeral resource. Defaults to the
+	// min_version of the resource.
+	MinVersion string `yaml:"min_version"`
+
+	// [Optional] The name of the ephemeral resource, in the same format as
+	// the resource name, eg: `GeneratedCert`. Defaults to the resource name.
+	Name string
+
+	// [Optional] The url of the method called when the ephemeral resource is
+	// opened, relative to the product base url, eg: `{{name}}:access`.
+	// Defaults to the self link of the resource.
+	Url string
+
+	// [Optional] The HTTP verb of the method. Defaults to GET.
+	Verb string
+
+	// [Optional] Arguments sent in the request body of the method, for
+	// generate methods.
+	Parameters []*Type
+
+	// [Required] Fields of the response returned by the ephemeral resource.
+	// They are output fields; mark secrets with `sensitive`.
+	Properties []*Type
+}
+
+func (e *Ephemeral) SetDefault(r *Resource) {
+	if e.MinVersion == "" {
+		e.MinVersion = r.MinVersion
+	}
+	if e.Url == "" {
+		e.Url = r.SelfLinkUri()
+	}
+	if e.Verb == "" {
+		e.Verb = "GET"
+	}
+
+	// Ephemeral fields are in the same package as the fields of the resource,
+	// so their expanders and flatteners need another name.
+	prefix := fmt.Sprintf("Ephemeral%s", r.ResourceName())
+	for _, p := range e.Parameters {
+		p.Prefix = prefix
+		p.SetDefault(r)
+	}
+	for _, p := range e.Properties {
+		p.Prefix = prefix
+		p.SetDefault(r)
+		for _, np := range r.AllNestedProperties([]*Type{p}) {
+			np.Output = true
+		}
+	}
+}
+
+func (e *Ephemeral) Validate(r *Resource, diags *google.Diagnostics) {
+	if len(e.Properties) == 0 {
+		diags.Errorf(r.SourceYamlFile, "", "Missing `properties` in `ephemeral` of resource %s", r.Name)
+	}
+
+	params := r.ExtractIdentifiers(e.Url)
+	fields := google.Concat(e.Parameters, e.Properties)
+	for _, p := range fields {
+		p.Validate(r.Name, diags)
+		if slices.Contains(params, google.Underscore(p.Name)) {
+			diags.Errorf(r.SourceYamlFile, "", "`ephemeral` field %s is already a parameter of the url in resource %s", p.Name, r.Name)
+		}
+	}
+
+	for _, p := range r.AllNestedProperties(fields) {
+		var unsupported string
+		switch {
+		case p.FrameworkType() == "":
+			unsupported = fmt.Sprintf("type %s", p.Type)
+		case p.CustomExpand != "" || p.CustomFlatten != "":
+			unsupported = "custom expanders and flatteners"
+		case p.DefaultValue != nil || p.WriteOnly || p.IsSet || p.FlattenObject:
+			unsupported = "default_value, write_only, is_set and flatten_object"
+		}
+		if unsupported != "" {
+			diags.Errorf(r.SourceYamlFile, "", "`ephemeral` does not support %s on field %s of resource %s", unsupported, p.Lineage(), r.Name)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"unicode"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
@@ -75,6 +76,8 @@ type Product struct {
 
 	// The compiler to generate the downstream files, for example "terraformgoogleconversion-codegen".
 	Compiler string `yaml:"-"`
+
+	SourceYamlFile string `yaml:"-"`
 }
 
 func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
@@ -91,15 +94,15 @@ func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+func (p *Product) Validate(diags *google.Diagnostics) {
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		diags.Errorf(p.SourceYamlFile, "", "Missing `name` for product")
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			diags.Errorf(p.SourceYamlFile, "", "product name `%s` must start with a capital letter.", p.Name)
 		}
 		if i == 0 {
 			break
@@ -107,19 +110,19 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		diags.Errorf(p.SourceYamlFile, "", "Missing `scopes` for product %s", p.Name)
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		diags.Errorf(p.SourceYamlFile, "", "Missing `versions` for product %s", p.Name)
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		v.Validate(p.Name, p.SourceYamlFile, diags)
 	}
 
 	if p.Async != nil {
-		p.Async.Validate()
+		p.Async.Validate(p.SourceYamlFile, diags)
 	}
 }
 
@@ -282,15 +285,37 @@ func (p Product) Lineage() string {
 	return p.Name
 }
 
+// Merges otherObj, read from an override file, into self. Fields left empty
+// in otherObj are skipped, except `required` and the fields listed in its
+// `$replace` directive, and lists are merged with DeepMerge.
 func Merge(self, otherObj reflect.Value) {
-
 	selfObj := reflect.Indirect(self)
+	otherObj = reflect.Indirect(otherObj)
+
+	replace := mergeDirectives(otherObj).Replace
+	for _, key := range replace {
+		if !slices.ContainsFunc(reflect.VisibleFields(selfObj.Type()), func(f reflect.StructField) bool { return yamlKey(f) == key }) {
+			log.Fatalf("Unknown field `%s` in `$replace` of %s %s", key, selfObj.Type().Name(), entryName(otherObj))
+		}
+	}
+
 	for i := 0; i < selfObj.NumField(); i++ {
+		field := selfObj.Type().Field(i)
+
+		// directives only apply to the merge itself
+		if field.Type == mergeDirectivesType {
+			continue
+		}
+
+		if slices.Contains(replace, yamlKey(field)) {
+			selfObj.Field(i).Set(otherObj.Field(i))
+			continue
+		}
 
 		// skip if the override is the "empty" value
 		emptyOverrideValue := reflect.DeepEqual(reflect.Zero(otherObj.Field(i).Type()).Interface(), otherObj.Field(i).Interface())
 
-		if emptyOverrideValue && selfObj.Type().Field(i).Name != "Required" {
+		if emptyOverrideValue && field.Name != "Required" {
 			continue
 		}
 
@@ -302,73 +327,143 @@ func Merge(self, otherObj reflect.Value) {
 	}
 }
 
+// Merges the entries of arr2 into arr1 by name. Entries of arr2 are merged
+// into the arr1 entry with the same name, or appended if there is none, and
+// their `$delete` and `$position` directives are applied.
 func DeepMerge(arr1, arr2 reflect.Value) {
-	if arr1.Len() == 0 {
-		arr1.Set(arr2)
-		return
-	}
 	if arr2.Len() == 0 {
 		return
 	}
 
-	// Scopes is an array of standard strings. In which case return the
-	// version in the overrides. This allows scopes to be removed rather
-	// than allowing for a merge of the two arrays
-	if arr1.Index(0).Kind() == reflect.String {
+	// Lists of values without names, such as scopes, can't be merged entry
+	// by entry. In which case return the version in the overrides. This
+	// allows entries to be removed rather than allowing for a merge of the
+	// two arrays
+	elemType := arr1.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if nameField, ok := elemType.FieldByName("Name"); elemType.Kind() != reflect.Struct || !ok || nameField.Type.Kind() != reflect.String {
 		arr1.Set(arr2)
 		return
 	}
 
-	// Merge any elements that exist in both
-	for i := 0; i < arr1.Len(); i++ {
-		currentVal := arr1.Index(i)
-		pointer := currentVal.Kind() == reflect.Ptr
-		if pointer {
-			currentVal = currentVal.Elem()
+	merged := reflect.AppendSlice(reflect.MakeSlice(arr1.Type(), 0, arr1.Len()+arr2.Len()), arr1)
+	positions := make(map[string]string)
+	var positioned []string
+	for i := 0; i < arr2.Len(); i++ {
+		otherVal := reflect.Indirect(arr2.Index(i))
+		name := entryName(otherVal)
+		directives := mergeDirectives(otherVal)
+		if directives.Position != "" {
+			positions[name] = directives.Position
+			positioned = append(positioned, name)
 		}
-		var otherVal reflect.Value
-		for j := 0; j < arr2.Len(); j++ {
-			currentName := currentVal.FieldByName("Name").Interface()
-			tempOtherVal := arr2.Index(j)
-			if pointer {
-				tempOtherVal = tempOtherVal.Elem()
-			}
-			otherName := tempOtherVal.FieldByName("Name").Interface()
 
-			if otherName == currentName {
-				otherVal = tempOtherVal
-				break
+		j := indexByName(merged, name)
+		switch {
+		case directives.Delete:
+			if j >= 0 {
+				merged = removeEntry(merged, j)
 			}
-		}
-		if otherVal.IsValid() {
-			Merge(currentVal, otherVal)
+		case j >= 0:
+			Merge(merged.Index(j), otherVal)
+		default:
+			merged = reflect.Append(merged, arr2.Index(i))
+			clearMergeDirectives(reflect.Indirect(merged.Index(merged.Len() - 1)))
 		}
 	}
 
-	// Add any elements of arr2 that don't exist in arr1
-	for i := 0; i < arr2.Len(); i++ {
-		otherVal := arr2.Index(i)
-		pointer := otherVal.Kind() == reflect.Ptr
-		if pointer {
-			otherVal = otherVal.Elem()
-		}
+	// Entries are moved once every entry is merged, so that they can be
+	// positioned relative to entries added later in the override
+	for _, name := range positioned {
+		merged = moveEntry(merged, name, positions[name])
+	}
 
-		found := false
-		for j := 0; j < arr1.Len(); j++ {
-			currentVal := arr1.Index(j)
-			if pointer {
-				currentVal = currentVal.Elem()
-			}
-			currentName := currentVal.FieldByName("Name").Interface()
-			otherName := otherVal.FieldByName("Name").Interface()
+	arr1.Set(merged)
+}
 
-			if otherName == currentName {
-				found = true
-				break
-			}
+var mergeDirectivesType = reflect.TypeOf(resource.MergeDirectives{})
+
+// Returns the merge directives of an entry of an override file, if its type
+// supports them.
+func mergeDirectives(v reflect.Value) resource.MergeDirectives {
+	if field := v.FieldByName("MergeDirectives"); field.IsValid() && field.Type() == mergeDirectivesType {
+		return field.Interface().(resource.MergeDirectives)
+	}
+	return resource.MergeDirectives{}
+}
+
+// Clears the merge directives of an entry added from an override file, if its
+// type supports them.
+func clearMergeDirectives(v reflect.Value) {
+	if field := v.FieldByName("MergeDirectives"); field.IsValid() && field.Type() == mergeDirectivesType {
+		field.SetZero()
+	}
+}
+
+// Returns the name of an entry, or an empty string if it has none.
+func entryName(v reflect.Value) string {
+	if field := v.FieldByName("Name"); field.IsValid() && field.Kind() == reflect.String {
+		return field.String()
+	}
+	return ""
+}
+
+// Returns the key yaml.v2 uses for field: the name from the yaml tag, or the
+// lowercased field name when the tag does not set one.
+func yamlKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func indexByName(list reflect.Value, name string) int {
+	for i := 0; i < list.Len(); i++ {
+		if entryName(reflect.Indirect(list.Index(i))) == name {
+			return i
 		}
-		if !found {
-			arr1.Set(reflect.Append(arr1, arr2.Index(i)))
+	}
+	return -1
+}
+
+func removeEntry(list reflect.Value, i int) reflect.Value {
+	return reflect.AppendSlice(list.Slice(0, i), list.Slice(i+1, list.Len()))
+}
+
+// Moves the entry named name to position: `first`, `last`, `before:<name>`
+// or `after:<name>`.
+func moveEntry(list reflect.Value, name, position string) reflect.Value {
+	i := indexByName(list, name)
+	if i < 0 {
+		// the entry was deleted
+		return list
+	}
+	entry := reflect.ValueOf(list.Index(i).Interface())
+	list = removeEntry(list, i)
+
+	j := -1
+	if before, ok := strings.CutPrefix(position, "before:"); ok {
+		j = indexByName(list, before)
+	} else if after, ok := strings.CutPrefix(position, "after:"); ok {
+		if j = indexByName(list, after); j >= 0 {
+			j++
 		}
+	} else if position == "first" {
+		j = 0
+	} else if position == "last" {
+		j = list.Len()
+	} else {
+		log.Fatalf("Unknown `$position` %q of %s, expected one of first, last, before:<name> or after:<name>", position, name)
+	}
+	if j < 0 {
+		log.Fatalf("Cannot find the entry of `$position` %q of %s", position, name)
 	}
+
+	moved := reflect.MakeSlice(list.Type(), 0, list.Len()+1)
+	moved = reflect.AppendSlice(moved, list.Slice(0, j))
+	moved = reflect.Append(moved, entry)
+	return reflect.AppendSlice(moved, list.Slice(j, list.Len()))
 }
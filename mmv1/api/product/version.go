@@ -14,8 +14,7 @@
 package product
 
 import (
-	"log"
-
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
@@ -32,12 +31,12 @@ type Version struct {
 	Name             string
 }
 
-func (v *Version) Validate(pName string) {
+func (v *Version) Validate(pName, yamlPath string, diags *google.Diagnostics) {
 	if v.Name == "" {
-		log.Fatalf("Missing `name` in `version` for product %s", pName)
+		diags.Errorf(yamlPath, "", "Missing `name` in `version` for product %s", pName)
 	}
 	if v.BaseUrl == "" {
-		log.Fatalf("Missing `base_url` in `version` for product %s", pName)
+		diags.Errorf(yamlPath, "", "Missing `base_url` in `version` for product %s", pName)
 	}
 }
 
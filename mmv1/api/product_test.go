@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"gopkg.in/yaml.v2"
 )
 
 func TestProductLowestVersion(t *testing.T) {
@@ -238,3 +239,277 @@ func TestProductServiceVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		base        string
+		override    string
+		expected    string
+	}{
+		{
+			description: "properties and examples are merged by name",
+			base: `
+name: Topic
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+    primary_resource_id: example
+properties:
+  - name: name
+    type: String
+    required: true
+`,
+			override: `
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+    exclude_test: true
+properties:
+  - name: name
+    description: The name.
+    required: true
+  - name: labels
+    type: KeyValueLabels
+`,
+			expected: `
+name: Topic
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+    primary_resource_id: example
+    exclude_test: true
+properties:
+  - name: name
+    type: String
+    description: The name.
+    required: true
+  - name: labels
+    type: KeyValueLabels
+`,
+		},
+		{
+			description: "$delete removes properties, nested properties and examples",
+			base: `
+name: Topic
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: name
+    type: String
+  - name: config
+    type: NestedObject
+    properties:
+      - name: foo
+        type: String
+      - name: bar
+        type: String
+  - name: labels
+    type: KeyValueLabels
+`,
+			override: `
+examples:
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+    $delete: true
+properties:
+  - name: config
+    properties:
+      - name: bar
+        $delete: true
+  - name: labels
+    $delete: true
+  - name: missing
+    $delete: true
+`,
+			expected: `
+name: Topic
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: name
+    type: String
+  - name: config
+    type: NestedObject
+    properties:
+      - name: foo
+        type: String
+`,
+		},
+		{
+			description: "$replace sets empty values and replaces lists",
+			base: `
+name: Topic
+immutable: true
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: config
+    type: NestedObject
+    immutable: true
+    properties:
+      - name: foo
+        type: String
+      - name: bar
+        type: String
+`,
+			override: `
+$replace: ['immutable', 'examples']
+examples:
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: config
+    $replace: ['immutable', 'properties']
+    properties:
+      - name: baz
+        type: String
+`,
+			expected: `
+name: Topic
+examples:
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: config
+    type: NestedObject
+    properties:
+      - name: baz
+        type: String
+`,
+		},
+		{
+			description: "$position moves merged and added entries",
+			base: `
+name: Topic
+examples:
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: a
+  - name: b
+  - name: c
+`,
+			override: `
+examples:
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+    $position: first
+properties:
+  - name: a
+    $position: last
+  - name: d
+    $position: before:b
+  - name: e
+    $position: after:d
+`,
+			expected: `
+name: Topic
+examples:
+  - name: topic_full
+    config_path: testdata/example.tf.tmpl
+  - name: topic_basic
+    config_path: testdata/example.tf.tmpl
+properties:
+  - name: d
+  - name: e
+  - name: b
+  - name: c
+  - name: a
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var base, override, expected Resource
+			for _, obj := range []struct {
+				content string
+				target  *Resource
+			}{{tc.base, &base}, {tc.override, &override}, {tc.expected, &expected}} {
+				if err := yaml.UnmarshalStrict([]byte(obj.content), obj.target); err != nil {
+					t.Fatalf("failed to parse yaml: %v", err)
+				}
+			}
+
+			Merge(reflect.ValueOf(&base), reflect.ValueOf(override))
+
+			// Compares the YAML fields, as examples also hold their rendered
+			// configs
+			got, err := yaml.Marshal(base)
+			if err != nil {
+				t.Fatalf("failed to marshal yaml: %v", err)
+			}
+			want, err := yaml.Marshal(expected)
+			if err != nil {
+				t.Fatalf("failed to marshal yaml: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("expected merged resource\n%s\nto be\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMerge_productVersions(t *testing.T) {
+	t.Parallel()
+
+	var base, override, expected Product
+	for _, obj := range []struct {
+		content string
+		target  *Product
+	}{
+		{`
+name: Pubsub
+versions:
+  - name: ga
+    base_url: https://pubsub.googleapis.com/v1/
+`, &base},
+		{`
+versions:
+  - name: ga
+    base_url: https://pubsub.googleapis.com/v2/
+  - name: beta
+    base_url: https://pubsub.googleapis.com/v1beta/
+`, &override},
+		{`
+name: Pubsub
+versions:
+  - name: ga
+    base_url: https://pubsub.googleapis.com/v2/
+  - name: beta
+    base_url: https://pubsub.googleapis.com/v1beta/
+`, &expected},
+	} {
+		if err := yaml.UnmarshalStrict([]byte(obj.content), obj.target); err != nil {
+			t.Fatalf("failed to parse yaml: %v", err)
+		}
+	}
+
+	Merge(reflect.ValueOf(&base), reflect.ValueOf(override))
+
+	got, err := yaml.Marshal(base)
+	if err != nil {
+		t.Fatalf("failed to marshal yaml: %v", err)
+	}
+	want, err := yaml.Marshal(expected)
+	if err != nil {
+		t.Fatalf("failed to marshal yaml: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("expected merged product\n%s\nto be\n%s", got, want)
+	}
+}
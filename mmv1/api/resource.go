@@ -115,9 +115,23 @@ type Resource struct {
 	// [Optional] The HTTP verb used during update. Defaults to PUT.
 	UpdateVerb string `yaml:"update_verb,omitempty"`
 
+	// [Optional] Customizes the requests of groups of fields updated with
+	// their own `update_url`, e.g. to nest the fields under a wrapper key.
+	// Each entry applies to the fields with the same `update_url` and
+	// `update_id`.
+	UpdateMethods []*resource.UpdateMethod `yaml:"update_methods,omitempty"`
+
+	// [Optional] Sends the etag of the resource along update and delete
+	// requests, and retries requests rejected because it is stale.
+	Etag *resource.Etag `yaml:"etag,omitempty"`
+
 	// [Optional] The HTTP verb used during delete. Defaults to DELETE.
 	DeleteVerb string `yaml:"delete_verb,omitempty"`
 
+	// [Optional] Combines the create requests of resources sent at the same
+	// time into a single request to a batch endpoint.
+	Batching *resource.Batching `yaml:"batching,omitempty"`
+
 	// [Optional] Additional Query Parameters to append to GET. Defaults to ""
 	ReadQueryParams string `yaml:"read_query_params,omitempty"`
 
@@ -159,6 +173,30 @@ type Resource struct {
 	// TODO rewrite: rename?
 	ExcludeResource bool `yaml:"exclude_resource,omitempty"`
 
+	// ====================
+	// Data Source Configuration
+	// ====================
+	//
+	// [Optional] (Api::Resource::Datasource) Configuration of a singular
+	// data source that reads the resource.
+	Datasource *resource.Datasource `yaml:"datasource,omitempty"`
+
+	// [Optional] (Api::Resource::ListDatasource) Configuration of a plural
+	// data source that lists the resources of a collection.
+	ListDatasource *resource.ListDatasource `yaml:"list_datasource,omitempty"`
+
+	// [Optional] (Api::Resource::ListResource) Configuration of a list
+	// resource that finds the resources to import with `terraform query`.
+	ListResource *resource.ListResource `yaml:"list_resource,omitempty"`
+
+	// [Optional] (Api::Ephemeral) Configuration of an ephemeral resource
+	// that returns values of the API without storing them in the state.
+	Ephemeral *Ephemeral `yaml:"ephemeral,omitempty"`
+
+	// [Optional] (Api::Resource::IdFunctions) Configuration of the provider
+	// functions building and parsing the id of the resource.
+	IdFunctions *resource.IdFunctions `yaml:"id_functions,omitempty"`
+
 	// [Optional] GCP kind, e.g. `compute//disk`
 	Kind string `yaml:"kind,omitempty"`
 
@@ -220,12 +258,19 @@ type Resource struct {
 	// corresponding OiCS walkthroughs.
 	Examples []resource.Examples
 
+	// If true, the resource is generated with the Terraform plugin framework
+	// instead of the SDK, and served by the plugin framework provider.
+	PluginFramework bool `yaml:"plugin_framework,omitempty"`
+
 	// If true, generates product operation handling logic.
 	AutogenAsync bool `yaml:"autogen_async,omitempty"`
 
 	// If true, resource is not importable
 	ExcludeImport bool `yaml:"exclude_import,omitempty"`
 
+	// If true, the resource has no resource identity
+	ExcludeIdentity bool `yaml:"exclude_identity,omitempty"`
+
 	// If true, exclude resource from Terraform Validator
 	// (i.e. terraform-provider-conversion)
 	ExcludeTgc bool `yaml:"exclude_tgc,omitempty"`
@@ -362,6 +407,10 @@ type Resource struct {
 
 	ImportPath     string `yaml:"-"`
 	SourceYamlFile string `yaml:"-"`
+
+	// Directives of an override file, eg: `$replace`. See
+	// resource.MergeDirectives.
+	MergeDirectives resource.MergeDirectives `yaml:",inline"`
 }
 
 func (r *Resource) UnmarshalYAML(unmarshal func(any) error) error {
@@ -416,19 +465,50 @@ func (r *Resource) SetDefault(product *Product) {
 	if r.IamPolicy != nil && r.IamPolicy.MinVersion == "" {
 		r.IamPolicy.MinVersion = r.MinVersion
 	}
+	if r.Datasource != nil {
+		if r.Datasource.MinVersion == "" {
+			r.Datasource.MinVersion = r.MinVersion
+		}
+		r.Datasource.SetDefaultFields(r.ExtractIdentifiers(r.IdFormat))
+	}
+	if r.ListDatasource != nil && r.ListDatasource.MinVersion == "" {
+		r.ListDatasource.MinVersion = r.MinVersion
+	}
+	if r.ListResource != nil && r.ListResource.MinVersion == "" {
+		r.ListResource.MinVersion = r.MinVersion
+	}
+	if r.Ephemeral != nil {
+		r.Ephemeral.SetDefault(r)
+	}
+	if r.IdFunctions != nil && r.IdFunctions.MinVersion == "" {
+		r.IdFunctions.MinVersion = r.MinVersion
+	}
+	for _, m := range r.UpdateMethods {
+		m.SetDefault()
+	}
+	if r.Etag != nil {
+		r.Etag.SetDefault()
+	}
+	if r.Batching != nil {
+		r.Batching.SetDefault()
+	}
 	if r.Timeouts == nil {
 		r.Timeouts = NewTimeouts()
 	}
 
 }
 
-func (r *Resource) Validate() {
+func (r *Resource) Validate(diags *google.Diagnostics) {
 	if r.Name == "" {
-		log.Fatalf("Missing `name` for resource")
+		diags.Errorf(r.SourceYamlFile, "", "Missing `name` for resource")
+	}
+
+	if !r.MergeDirectives.IsEmpty() {
+		diags.Errorf(r.SourceYamlFile, "", "`$delete`, `$replace` and `$position` are only supported in override files")
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		log.Fatalf("`is_list_of_ids: true` implies resource has exactly one `identity` property")
+		diags.Errorf(r.SourceYamlFile, "", "`is_list_of_ids: true` implies resource has exactly one `identity` property")
 	}
 
 	// Ensures we have all properties defined
@@ -437,58 +517,94 @@ func (r *Resource) Validate() {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			log.Fatalf("Missing property/parameter for identity %s", i)
+			diags.Errorf(r.SourceYamlFile, "", "Missing property/parameter for identity %s", i)
 		}
 	}
 
 	if r.Description == "" {
-		log.Fatalf("Missing `description` for resource %s", r.Name)
+		diags.Errorf(r.SourceYamlFile, "", "Missing `description` for resource %s", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			log.Fatalf("Missing `properties` for resource %s", r.Name)
+			diags.Errorf(r.SourceYamlFile, "", "Missing `properties` for resource %s", r.Name)
 		}
 	}
 
 	allowed := []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.CreateVerb) {
-		log.Fatalf("Value on `create_verb` should be one of %#v", allowed)
+		diags.Errorf(r.SourceYamlFile, "", "Value on `create_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"GET", "POST"}
 	if !slices.Contains(allowed, r.ReadVerb) {
-		log.Fatalf("Value on `read_verb` should be one of %#v", allowed)
+		diags.Errorf(r.SourceYamlFile, "", "Value on `read_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
 	if !slices.Contains(allowed, r.DeleteVerb) {
-		log.Fatalf("Value on `delete_verb` should be one of %#v", allowed)
+		diags.Errorf(r.SourceYamlFile, "", "Value on `delete_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.UpdateVerb) {
-		log.Fatalf("Value on `update_verb` should be one of %#v", allowed)
+		diags.Errorf(r.SourceYamlFile, "", "Value on `update_verb` should be one of %#v", allowed)
 	}
 
 	for _, property := range r.AllProperties() {
-		property.Validate(r.Name)
+		property.Validate(r.Name, diags)
 	}
 
 	if r.IamPolicy != nil {
-		r.IamPolicy.Validate(r.Name)
+		r.IamPolicy.Validate(r.Name, r.SourceYamlFile, diags)
+	}
+
+	if r.Datasource != nil {
+		r.Datasource.Validate(r.Name, r.SourceYamlFile, r.ExtractIdentifiers(r.IdFormat), diags)
+	}
+
+	if r.ListDatasource != nil && r.NestedQuery != nil {
+		diags.Errorf(r.SourceYamlFile, "", "`list_datasource` is not supported with `nested_query` in resource %s", r.Name)
+	}
+
+	if r.ListResource != nil {
+		r.validateListResource(diags)
+	}
+
+	if r.PluginFramework {
+		r.validatePluginFramework(diags)
+	}
+
+	if r.Ephemeral != nil {
+		r.Ephemeral.Validate(r, diags)
+	}
+
+	if r.IdFunctions != nil {
+		r.validateIdFunctions(diags)
+	}
+
+	if len(r.UpdateMethods) > 0 {
+		r.validateUpdateMethods(diags)
+	}
+
+	if r.Etag != nil {
+		r.validateEtag(diags)
+	}
+
+	if r.Batching != nil {
+		r.validateBatching(diags)
 	}
 
 	if r.NestedQuery != nil {
-		r.NestedQuery.Validate(r.Name)
+		r.NestedQuery.Validate(r.Name, r.SourceYamlFile, diags)
 	}
 
 	for _, example := range r.Examples {
-		example.Validate(r.Name)
+		example.Validate(r.Name, r.SourceYamlFile, diags)
 	}
 
 	if r.Async != nil {
-		r.Async.Validate()
+		r.Async.Validate(r.SourceYamlFile, diags)
 	}
 }
 
@@ -588,6 +704,20 @@ func (r Resource) WriteOnlyProps() []*Type {
 	})
 }
 
+// Returns the dot notation paths of the sensitive and write-only fields in
+// API requests and responses, eg: payload.data
+func (r Resource) SensitiveApiFields() []string {
+	var fields []string
+	for _, prop := range google.Concat(r.SensitiveProps(), r.WriteOnlyProps()) {
+		if prop.UrlParamOnly {
+			continue
+		}
+		fields = append(fields, prop.MetadataApiLineage())
+	}
+	slices.Sort(fields)
+	return slices.Compact(fields)
+}
+
 func (r Resource) SensitivePropsToString() string {
 	var props []string
 
@@ -797,6 +927,7 @@ func buildEffectiveLabelsField(name string, labels *Type) *Type {
 		propertyWithMinVersion(labels.fieldMinVersion()),
 		propertyWithUpdateVerb(labels.UpdateVerb),
 		propertyWithUpdateUrl(labels.UpdateUrl),
+		propertyWithFingerprintName(labels.FingerprintName),
 		propertyWithImmutable(labels.Immutable),
 	}
 	return NewProperty(n, name, options)
@@ -835,6 +966,16 @@ func (r Resource) RootLabels() bool {
 	return false
 }
 
+// Check if the resource has root "annotations" field
+func (r Resource) RootAnnotations() bool {
+	for _, p := range r.RootProperties() {
+		if p.IsA("KeyValueAnnotations") {
+			return true
+		}
+	}
+	return false
+}
+
 // Return labels fields that should be added to ImportStateVerifyIgnore
 func (r Resource) IgnoreReadLabelsFields(props []*Type) []string {
 	fields := make([]string, 0)
@@ -1489,6 +1630,423 @@ func (r Resource) ExamplePrimaryResourceId() string {
 	return examples[0].PrimaryResourceId
 }
 
+// Returns true if the data source of the resource is generated for the
+// target version.
+func (r Resource) GeneratesDatasource() bool {
+	if r.Datasource == nil || r.Datasource.Exclude || r.IsExcluded() {
+		return false
+	}
+	return r.Datasource.MinVersion == "" || slices.Index(product.ORDER, r.Datasource.MinVersion) <= slices.Index(product.ORDER, r.TargetVersionName)
+}
+
+// Name of the function returning the data source, matching the handwritten
+// data sources, eg: DataSourceGooglePubsubTopic
+func (r Resource) DatasourceFunctionName() string {
+	return fmt.Sprintf("DataSourceGoogle%s", r.ResourceName())
+}
+
+// Returns the documentation of a field used to find the resource in its
+// data source.
+func (r Resource) DatasourceArgumentDescription(field string) string {
+	for _, p := range r.AllUserProperties() {
+		if google.Underscore(p.Name) == field && p.Description != "" {
+			return strings.TrimSpace(p.Description)
+		}
+	}
+	switch field {
+	case "project":
+		return "The project in which the resource belongs. If it is not provided, the provider project is used."
+	case "region", "zone":
+		return fmt.Sprintf("The %s in which the resource belongs. If it is not provided, the provider %s is used.", field, field)
+	}
+	return ""
+}
+
+// Returns true if the plural data source of the resource is generated for
+// the target version.
+func (r Resource) GeneratesListDatasource() bool {
+	if r.ListDatasource == nil || r.ListDatasource.Exclude || r.IsExcluded() {
+		return false
+	}
+	return r.ListDatasource.MinVersion == "" || slices.Index(product.ORDER, r.ListDatasource.MinVersion) <= slices.Index(product.ORDER, r.TargetVersionName)
+}
+
+// Name of the function returning the plural data source, eg:
+// DataSourceGooglePubsubTopics
+func (r Resource) ListDatasourceFunctionName() string {
+	return fmt.Sprintf("DataSourceGoogle%s", r.ListDatasourceResourceName())
+}
+
+// Terraform name of the plural data source, eg: google_pubsub_topics
+func (r Resource) ListDatasourceTerraformName() string {
+	return google.PluralNoun(r.TerraformName())
+}
+
+// Plural of the resource name, eg: Topics
+func (r Resource) ListDatasourcePluralName() string {
+	return google.PluralNoun(r.Name)
+}
+
+// Plural of the resource name used in generated Go names, eg: PubsubTopics
+func (r Resource) ListDatasourceResourceName() string {
+	return google.PluralNoun(r.ResourceName())
+}
+
+// Name of the attribute holding the resources in the plural data source,
+// eg: topics
+func (r Resource) ListDatasourceAttribute() string {
+	return google.Underscore(r.ListDatasourcePluralName())
+}
+
+// Returns the uri listed by the plural data source and the list resource,
+// relative to the product base url. Zonal compute resources are listed across
+// zones with aggregated lists, like in ListUrlTemplate.
+func (r Resource) ListUri() string {
+	uri := strings.Split(r.collectionUri(), "?")[0]
+	return strings.Replace(uri, "zones/{{zone}}", "aggregated", 1)
+}
+
+// Returns true if the list uri lists every zone at once.
+func (r Resource) ListAggregated() bool {
+	return strings.Contains(r.ListUri(), "/aggregated/")
+}
+
+// Returns the parameters of the list uri, which are the arguments of the
+// plural data source and the list resource.
+func (r Resource) ListParams() []string {
+	return r.ExtractIdentifiers(r.ListUri())
+}
+
+// Returns true if the list resource of the resource is generated for the
+// target version.
+func (r Resource) GeneratesListResource() bool {
+	if r.ListResource == nil || r.ListResource.Exclude || r.IsExcluded() {
+		return false
+	}
+	return r.ListResource.MinVersion == "" || slices.Index(product.ORDER, r.ListResource.MinVersion) <= slices.Index(product.ORDER, r.TargetVersionName)
+}
+
+// Name of the function returning the list resource, eg:
+// NewPubsubTopicListResource
+func (r Resource) ListResourceFunctionName() string {
+	return fmt.Sprintf("New%sListResource", r.ResourceName())
+}
+
+// Returns the attributes of the resource identity, which are the fields of
+// the id format.
+func (r Resource) IdentityAttributes() []string {
+	return r.ExtractIdentifiers(r.GetIdFormat())
+}
+
+// Returns true if the resource has a resource identity, set on create and
+// read and accepted on import in place of an import id. Every attribute of the
+// identity must be a string field of the resource, so resources whose id
+// format uses other fields have no identity. Neither do resources with a
+// custom import, as it only parses import ids.
+func (r Resource) HasIdentity() bool {
+	attributes := r.IdentityAttributes()
+	if len(attributes) == 0 || r.IsExcluded() || r.ExcludeIdentity || r.CustomCode.CustomImport != "" {
+		return false
+	}
+	for _, attribute := range attributes {
+		if attribute == "project" && r.HasProject() {
+			continue
+		}
+		props := r.RootProperties()
+		i := slices.IndexFunc(props, func(p *Type) bool {
+			return google.Underscore(p.Name) == attribute
+		})
+		if i < 0 || props[i].TFType(props[i].Type) != "schema.TypeString" {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the readable top-level property set from an identity attribute, or
+// nil if the attribute is not a property.
+func (r Resource) IdentityProperty(attribute string) *Type {
+	for _, p := range r.ReadProperties() {
+		if google.Underscore(p.Name) == attribute && !p.FlattenObject {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the literal values of a list parameter in the sweeper url
+// substitutions, which are listed in turn when the parameter is not set.
+// Values read from the test environment are skipped, as well as project,
+// region and zone, which default to the provider configuration.
+func (r Resource) ListResourceDefaultValues(param string) []string {
+	if param == "project" || param == "region" || param == "zone" {
+		return nil
+	}
+	var values []string
+	for _, substitutions := range r.Sweeper.URLSubstitutions {
+		v, ok := substitutions[param]
+		if !ok || r.Sweeper.EnvVarInterpolate(v) != fmt.Sprintf("%q", v) || slices.Contains(values, v) {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// Returns the parent resource listed to fill the child field of the sweeper
+// parent, or nil if there is none. The parent must be in the same product and
+// listable from the parameters of the list resource.
+func (r Resource) ListResourceParent() *Resource {
+	if r.Sweeper.Parent == nil || !slices.Contains(r.ListParams(), r.Sweeper.Parent.ChildField) {
+		return nil
+	}
+	for _, o := range r.ProductMetadata.Objects {
+		if o.TerraformName() != r.Sweeper.Parent.ResourceType || o.Exclude || o.NotInVersion(r.ProductMetadata.VersionObjOrClosest(r.TargetVersionName)) {
+			continue
+		}
+		for _, param := range o.ListParams() {
+			if param == r.Sweeper.Parent.ChildField || !slices.Contains(r.ListParams(), param) {
+				return nil
+			}
+		}
+		return o
+	}
+	return nil
+}
+
+// Returns true if field is a parameter of the list uri.
+func (r Resource) IsListParam(field string) bool {
+	return slices.Contains(r.ListParams(), field)
+}
+
+// Returns true if a list parameter has default values, so a query can list
+// several collections.
+func (r Resource) ListResourceExpandsQueries() bool {
+	for _, param := range r.ListParams() {
+		if len(r.ListResourceDefaultValues(param)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if a list parameter can be left unset in the query.
+func (r Resource) ListResourceOptionalParam(param string) bool {
+	switch {
+	case param == "project" || param == "region" || param == "zone":
+		return true
+	case len(r.ListResourceDefaultValues(param)) > 0:
+		return true
+	case r.ListResourceParent() != nil && param == r.Sweeper.Parent.ChildField:
+		return true
+	}
+	return false
+}
+
+func (r Resource) validateListResource(diags *google.Diagnostics) {
+	if r.NestedQuery != nil {
+		diags.Errorf(r.SourceYamlFile, "", "`list_resource` is not supported with `nested_query` in resource %s", r.Name)
+	}
+	if r.ExcludeImport || r.ExcludeRead {
+		diags.Errorf(r.SourceYamlFile, "", "`list_resource` requires the resource %s to be readable and importable", r.Name)
+	}
+	if !r.HasIdentity() {
+		diags.Errorf(r.SourceYamlFile, "", "`list_resource` requires resource %s to have a resource identity, which needs an id format only using string fields of the resource and no `custom_import` or `exclude_identity`", r.Name)
+	}
+
+	attributes := r.IdentityAttributes()
+	for i, attribute := range attributes {
+		if r.IsListParam(attribute) || r.IdentityProperty(attribute) != nil || (attribute == "zone" && r.ListAggregated()) {
+			continue
+		}
+		// The last attribute is read from the name of the listed resources
+		// when it is not a property, eg: url_param_only names.
+		if i == len(attributes)-1 {
+			continue
+		}
+		diags.Errorf(r.SourceYamlFile, "", "`list_resource` cannot read %s of resource %s from the list response", attribute, r.Name)
+	}
+}
+
+// Name of the function returning the plugin framework resource, eg:
+// NewChronicleDataAccessLabelResource
+func (r Resource) FrameworkResourceFunctionName() string {
+	return fmt.Sprintf("New%sResource", r.ResourceName())
+}
+
+// Returns true if the custom code of the resource changes how it is read or
+// written, which the plugin framework template has no hooks for.
+func (r Resource) hasResourceCustomCode() bool {
+	cc := r.CustomCode
+	for _, c := range []string{cc.ExtraSchemaEntry, cc.Encoder, cc.UpdateEncoder, cc.Decoder, cc.PreCreate, cc.PostCreate, cc.PostCreateFailure, cc.CustomCreate, cc.PreRead, cc.PostRead, cc.PreUpdate, cc.PostUpdate, cc.CustomUpdate, cc.PreDelete, cc.PostDelete, cc.CustomDelete, cc.CustomImport, cc.PostImport, cc.ValidateRawResourceConfigFuncs} {
+		if c != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Resource) validatePluginFramework(diags *google.Diagnostics) {
+	for _, option := range []struct {
+		name string
+		set  bool
+	}{
+		{"custom_code", r.hasResourceCustomCode()},
+		{"custom_diff", len(r.CustomDiff) > 0},
+		{"nested_query", r.NestedQuery != nil},
+		{"virtual_fields", len(r.VirtualFields) > 0},
+		{"schema_version", r.SchemaVersion > 0 || r.StateUpgraders || r.MigrateState != ""},
+		{"datasource", r.Datasource != nil},
+		{"list_datasource", r.ListDatasource != nil},
+		{"list_resource", r.ListResource != nil},
+		{"read_error_transform", r.ReadErrorTransform != ""},
+		{"exclude_read", r.ExcludeRead},
+		{"legacy_long_form_project", r.LegacyLongFormProject},
+		{"supports_indirect_user_project_override", r.SupportsIndirectUserProjectOverride},
+	} {
+		if option.set {
+			diags.Errorf(r.SourceYamlFile, "", "`plugin_framework` is not supported with `%s` in resource %s", option.name, r.Name)
+		}
+	}
+	if async := r.GetAsync(); async != nil && !async.IsA("OpAsync") {
+		diags.Errorf(r.SourceYamlFile, "", "`plugin_framework` only supports `OpAsync` operations in resource %s", r.Name)
+	}
+	if r.HasPostCreateComputedFields() {
+		diags.Errorf(r.SourceYamlFile, "", "`plugin_framework` does not support id fields set by the API in resource %s", r.Name)
+	}
+	if r.HasRegion() || r.HasZone() {
+		diags.Errorf(r.SourceYamlFile, "", "`plugin_framework` does not support region and zone provider defaults in resource %s", r.Name)
+	}
+
+	for _, p := range r.AllNestedProperties(r.AllUserProperties()) {
+		var unsupported string
+		switch {
+		case p.FrameworkType() == "":
+			unsupported = fmt.Sprintf("type %s", p.Type)
+		case p.CustomExpand != "" || p.CustomFlatten != "":
+			unsupported = "custom expanders and flatteners"
+		case p.DiffSuppressFunc != "" || p.StateFunc != "" || p.Validation.Function != "" || p.ItemValidation.Function != "":
+			unsupported = "custom schema functions"
+		case p.IsSet || p.FlattenObject || p.UnorderedList:
+			unsupported = "is_set, flatten_object and unordered_list"
+		case p.UpdateUrl != "":
+			unsupported = "update_url"
+		case len(p.Conflicts) > 0 || len(p.AtLeastOneOf) > 0 || len(p.ExactlyOneOf) > 0 || len(p.RequiredWith) > 0:
+			unsupported = "conflicts, at_least_one_of, exactly_one_of and required_with"
+		case p.DefaultValue != nil && (p.FrameworkType() == "Object" || p.FrameworkType() == "List" || p.FrameworkType() == "Map"):
+			unsupported = "default_value on collections"
+		case p.ParentMetadata != nil && (p.WriteOnly || p.IgnoreRead || p.UrlParamOnly):
+			unsupported = "nested write_only, ignore_read and url_param_only"
+		}
+		if unsupported != "" {
+			diags.Errorf(r.SourceYamlFile, "", "`plugin_framework` does not support %s on field %s of resource %s", unsupported, p.Lineage(), r.Name)
+		}
+	}
+}
+
+// Returns true if the ephemeral resource of the resource is generated for the
+// target version.
+func (r Resource) GeneratesEphemeral() bool {
+	if r.Ephemeral == nil || r.Ephemeral.Exclude || r.IsExcluded() {
+		return false
+	}
+	return r.Ephemeral.MinVersion == "" || slices.Index(product.ORDER, r.Ephemeral.MinVersion) <= slices.Index(product.ORDER, r.TargetVersionName)
+}
+
+// Name of the ephemeral resource, eg: SecretManagerSecretVersion
+func (r Resource) EphemeralName() string {
+	if r.Ephemeral.Name == "" {
+		return r.ResourceName()
+	}
+	return fmt.Sprintf("%s%s", r.ProductMetadata.Name, r.Ephemeral.Name)
+}
+
+// Name of the function returning the ephemeral resource, eg:
+// NewSecretManagerSecretVersionEphemeralResource
+func (r Resource) EphemeralFunctionName() string {
+	return fmt.Sprintf("New%sEphemeralResource", r.EphemeralName())
+}
+
+// Terraform name of the ephemeral resource, eg:
+// google_secret_manager_secret_version
+func (r Resource) EphemeralTerraformName() string {
+	if r.Ephemeral.Name == "" {
+		return r.TerraformName()
+	}
+	return fmt.Sprintf("google_%s_%s", r.ProductMetadata.TerraformName(), google.Underscore(r.Ephemeral.Name))
+}
+
+// Returns the parameters of the ephemeral url, which are string arguments
+// of the ephemeral resource.
+func (r Resource) EphemeralParams() []string {
+	return r.ExtractIdentifiers(r.Ephemeral.Url)
+}
+
+// Returns true if the id functions of the resource are generated for the
+// target version.
+func (r Resource) GeneratesIdFunctions() bool {
+	if r.IdFunctions == nil || r.IdFunctions.Exclude || r.IsExcluded() {
+		return false
+	}
+	return r.IdFunctions.MinVersion == "" || slices.Index(product.ORDER, r.IdFunctions.MinVersion) <= slices.Index(product.ORDER, r.TargetVersionName)
+}
+
+// Name of the provider function building the id of the resource, eg:
+// compute_instance_id
+func (r Resource) IdFunctionName() string {
+	return fmt.Sprintf("%s_id", strings.TrimPrefix(r.TerraformName(), "google_"))
+}
+
+// Name of the provider function parsing the id of the resource, eg:
+// parse_compute_instance_id
+func (r Resource) ParseIdFunctionName() string {
+	return fmt.Sprintf("parse_%s", r.IdFunctionName())
+}
+
+func (r *Resource) validateIdFunctions(diags *google.Diagnostics) {
+	if len(r.ExtractIdentifiers(r.GetIdFormat())) == 0 {
+		diags.Errorf(r.SourceYamlFile, "", "`id_functions` requires fields in the id format of resource %s", r.Name)
+	}
+}
+
+// Returns the HCL arguments of the data source set from the primary resource
+// of an example.
+func (r Resource) DatasourceTestArguments(e resource.Examples) []string {
+	var args []string
+	for _, f := range append(slices.Clone(r.Datasource.RequiredFields), r.Datasource.OptionalFields...) {
+		args = append(args, fmt.Sprintf("%s = %s.%s.%s", f, e.ResourceType(r.TerraformName()), e.PrimaryResourceId, f))
+	}
+	return args
+}
+
+// Returns the fields that the data source cannot read back, as a Go map
+// literal, for CheckDataSourceStateMatchesResourceStateWithIgnores.
+func (r Resource) DatasourceIgnoreFieldsToString(e resource.Examples) string {
+	var fields []string
+	for _, tp := range r.AllUserProperties() {
+		if tp.UrlParamOnly || tp.IsA("ResourceRef") || tp.WriteOnly {
+			fields = append(fields, google.Underscore(tp.Name))
+		}
+	}
+	for _, vf := range r.VirtualFields {
+		fields = append(fields, google.Underscore(vf.Name))
+	}
+	fields = append(fields, e.IgnoreReadExtra...)
+	fields = append(fields, ignoreReadFields(r.AllUserProperties())...)
+
+	slices.Sort(fields)
+	fields = slices.Compact(fields)
+
+	if len(fields) == 0 {
+		return ""
+	}
+	var entries []string
+	for _, f := range fields {
+		entries = append(entries, fmt.Sprintf("\"%s\": {}", f))
+	}
+	return fmt.Sprintf("map[string]struct{}{%s}", strings.Join(entries, ", "))
+}
+
 func (r Resource) IamParentSourceType() string {
 	t := r.IamPolicy.ParentResourceType
 	if t == "" {
@@ -1756,6 +2314,94 @@ func (r Resource) PropertiesByCustomUpdateGroups() []UpdateGroup {
 	return updateGroups
 }
 
+// Returns the update method customizing the request of group, or nil.
+func (r Resource) UpdateMethodForGroup(group UpdateGroup) *resource.UpdateMethod {
+	for _, m := range r.UpdateMethods {
+		if m.UpdateUrl == group.UpdateUrl && m.UpdateId == group.UpdateId {
+			return m
+		}
+	}
+	return nil
+}
+
+// Returns how to wait for the update of group to finish, see
+// resource.UpdateMethod.Poll.
+func (r Resource) UpdatePoll(group UpdateGroup) string {
+	if m := r.UpdateMethodForGroup(group); m != nil && m.Poll != "" {
+		return m.Poll
+	}
+	return "async"
+}
+
+func (r *Resource) validateUpdateMethods(diags *google.Diagnostics) {
+	if r.CustomCode.CustomUpdate != "" {
+		diags.Errorf(r.SourceYamlFile, "", "`update_methods` are not used by resource %s, as it sets `custom_update`", r.Name)
+	}
+
+	groups := r.PropertiesByCustomUpdateGroups()
+	for _, m := range r.UpdateMethods {
+		m.Validate(r.Name, r.SourceYamlFile, diags)
+
+		matched := google.Select(groups, func(g UpdateGroup) bool { return r.UpdateMethodForGroup(g) == m })
+		if len(matched) == 0 {
+			diags.Errorf(r.SourceYamlFile, "", "Update method %s in resource %s doesn't match the `update_url` and `update_id` of any field", m.UpdateUrl, r.Name)
+		}
+		if m.FingerprintSource != "" && slices.ContainsFunc(matched, func(g UpdateGroup) bool { return g.FingerprintName == "" }) {
+			diags.Errorf(r.SourceYamlFile, "", "`fingerprint_source` of update method %s requires the `fingerprint_name` of its fields in resource %s", m.UpdateUrl, r.Name)
+		}
+
+		if m.Poll == "operation" && (r.GetAsync() == nil || !r.GetAsync().IsA("OpAsync")) {
+			diags.Errorf(r.SourceYamlFile, "", "`poll: operation` of update method %s requires an `OpAsync` async config in resource %s", m.UpdateUrl, r.Name)
+		}
+	}
+}
+
+// Returns the output field holding the etag of the resource, or nil.
+func (r Resource) EtagProperty() *Type {
+	if r.Etag == nil {
+		return nil
+	}
+	for _, p := range r.AllUserProperties() {
+		if p.Name == r.Etag.Field {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns whether the etag is sent along requests of method, eg: update.
+func (r Resource) SendsEtag(method string) bool {
+	return r.Etag != nil && r.Etag.Allow(method)
+}
+
+func (r *Resource) validateEtag(diags *google.Diagnostics) {
+	r.Etag.Validate(r.Name, r.SourceYamlFile, diags)
+
+	if p := r.EtagProperty(); p == nil || !p.Output || !(p.IsA("String") || p.IsA("Fingerprint")) {
+		diags.Errorf(r.SourceYamlFile, "", "`etag` requires an output String field named %s in resource %s", r.Etag.Field, r.Name)
+	}
+	if r.NestedQuery != nil {
+		diags.Errorf(r.SourceYamlFile, "", "`etag` is not supported by resource %s, as it sets `nested_query`", r.Name)
+	}
+	if r.SendsEtag("update") && r.CustomCode.CustomUpdate != "" {
+		diags.Errorf(r.SourceYamlFile, "", "`etag` isn't sent along updates of resource %s, as it sets `custom_update`", r.Name)
+	}
+	if r.SendsEtag("delete") && r.CustomCode.CustomDelete != "" {
+		diags.Errorf(r.SourceYamlFile, "", "`etag` isn't sent along deletes of resource %s, as it sets `custom_delete`", r.Name)
+	}
+}
+
+func (r *Resource) validateBatching(diags *google.Diagnostics) {
+	r.Batching.Validate(r.Name, r.SourceYamlFile, diags)
+
+	if r.CustomCode.CustomCreate != "" {
+		diags.Errorf(r.SourceYamlFile, "", "`batching` is not used by resource %s, as it sets `custom_create`", r.Name)
+	}
+	if r.NestedQuery != nil && r.NestedQuery.ModifyByPatch {
+		diags.Errorf(r.SourceYamlFile, "", "`batching` is not supported by resource %s, as it sets `nested_query.modify_by_patch`", r.Name)
+	}
+}
+
 func (r Resource) FieldSpecificUpdateMethods() bool {
 	return (len(r.PropertiesByCustomUpdate(r.RootProperties())) > 0)
 }
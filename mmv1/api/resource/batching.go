@@ -0,0 +1,74 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Combines the create requests of resources sent at the same time into a
+// single request to a batch endpoint, eg: Firestore batchWrite. Requests are
+// batched according to the provider's `batching` config. Batch endpoints
+// returning a single operation for the whole batch, eg: bulkInsert, aren't
+// supported.
+type Batching struct {
+	// The URL of the batch endpoint, relative to the product base url. It can
+	// contain fields of the resource, eg: {{project}}.
+	BatchUrl string `yaml:"batch_url"`
+
+	// Requests with the same key are combined into a batch. It can contain
+	// fields of the resource. Default: batch_url
+	BatchKey string `yaml:"batch_key"`
+
+	// The list field of the batch request body holding the body of each
+	// combined request.
+	RequestField string `yaml:"request_field"`
+
+	// The list field of the batch response holding the result of each
+	// combined request, in the order of `request_field`. If unset, each
+	// request gets the whole batch response.
+	ResponseField string `yaml:"response_field"`
+
+	// The field of a result in `response_field` holding the error of its
+	// request, if it failed. The other requests of the batch still succeed.
+	ErrorField string `yaml:"error_field"`
+
+	// The list field of the batch response holding the status of each
+	// combined request, in the order of `request_field`, for endpoints
+	// returning the results and errors of requests in separate lists, eg:
+	// status in Firestore batchWrite. A request whose status has a non-zero
+	// code fails, and the other requests of the batch still succeed.
+	StatusField string `yaml:"status_field"`
+}
+
+func (b *Batching) SetDefault() {
+	if b.BatchKey == "" {
+		b.BatchKey = b.BatchUrl
+	}
+}
+
+func (b *Batching) Validate(rName, yamlPath string, diags *google.Diagnostics) {
+	if b.BatchUrl == "" {
+		diags.Errorf(yamlPath, "", "Missing `batching.batch_url` in resource %s", rName)
+	}
+	if b.RequestField == "" {
+		diags.Errorf(yamlPath, "", "Missing `batching.request_field` in resource %s", rName)
+	}
+	if b.ErrorField != "" && b.ResponseField == "" {
+		diags.Errorf(yamlPath, "", "`batching.error_field` requires `batching.response_field` in resource %s", rName)
+	}
+	if b.ErrorField != "" && b.StatusField != "" {
+		diags.Errorf(yamlPath, "", "Only one of `batching.error_field` and `batching.status_field` can be set in resource %s", rName)
+	}
+}
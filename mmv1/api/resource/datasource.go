@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Information about the singular data source generated for this resource.
+// The data source reuses the schema of the resource, with every field
+// computed, and reads the resource from the id built from the fields set by
+// the user.
+type Datasource struct {
+	// boolean of if the data source should be generated
+	Exclude bool
+
+	// Fields the user must set to find the resource. Together with
+	// OptionalFields, they must cover every parameter of the resource's
+	// id_format. If neither is set, every parameter of the id_format is
+	// required except project, region and zone, which are optional and
+	// default to the provider configuration.
+	RequiredFields []string `yaml:"required_fields"`
+
+	// Fields the user can set to find the resource. They should fall back to a
+	// default, like project does with the provider configuration.
+	OptionalFields []string `yaml:"optional_fields"`
+
+	// Boolean of if the generated acceptance test should be skipped. The test
+	// creates the resource from its first testable example.
+	ExcludeTest bool `yaml:"exclude_test"`
+
+	// [Optional] The version of the data source. Defaults to the min_version
+	// of the resource.
+	MinVersion string `yaml:"min_version"`
+}
+
+// Fields with a default in the provider configuration.
+var providerDefaultFields = []string{"project", "region", "zone"}
+
+// Sets the fields used to find the resource from the parameters of its id
+// format, unless either list was set in the yaml.
+func (d *Datasource) SetDefaultFields(idParams []string) {
+	if len(d.RequiredFields) > 0 || len(d.OptionalFields) > 0 {
+		return
+	}
+	for _, p := range idParams {
+		if slices.Contains(providerDefaultFields, p) {
+			d.OptionalFields = append(d.OptionalFields, p)
+		} else {
+			d.RequiredFields = append(d.RequiredFields, p)
+		}
+	}
+}
+
+func (d *Datasource) Validate(rName, yamlPath string, idParams []string, diags *google.Diagnostics) {
+	for _, f := range d.RequiredFields {
+		if slices.Contains(d.OptionalFields, f) {
+			diags.Errorf(yamlPath, "", "Field %s is both required and optional in the datasource of resource %s", f, rName)
+		}
+	}
+
+	for _, p := range idParams {
+		if !slices.Contains(d.RequiredFields, p) && !slices.Contains(d.OptionalFields, p) {
+			diags.Errorf(yamlPath, "", "Parameter %s of the id format is missing from `required_fields` and `optional_fields` in the datasource of resource %s", p, rName)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Etag-based optimistic concurrency, see https://google.aip.dev/154.
+// The etag read into the state is sent along update and delete requests,
+// and requests rejected because it is stale are retried with the etag of
+// the resource read again.
+type Etag struct {
+	// The name of the output field holding the etag. Default: etag
+	Field string
+
+	// The requests sending the etag: update requests send it in the body,
+	// and delete requests as the `etag` query parameter.
+	// Default: ['update', 'delete']
+	Actions []string
+
+	// The number of times a request rejected with a stale etag (409 or 412)
+	// is retried. Default: 3
+	Retries int
+}
+
+func (e *Etag) SetDefault() {
+	if e.Field == "" {
+		e.Field = "etag"
+	}
+	if len(e.Actions) == 0 {
+		e.Actions = []string{"update", "delete"}
+	}
+	if e.Retries == 0 {
+		e.Retries = 3
+	}
+}
+
+func (e *Etag) Validate(rName, yamlPath string, diags *google.Diagnostics) {
+	for _, action := range e.Actions {
+		if action != "update" && action != "delete" {
+			diags.Errorf(yamlPath, "", "`etag.actions` of resource %s must be update or delete, got %s", rName, action)
+		}
+	}
+	if e.Retries < 0 {
+		diags.Errorf(yamlPath, "", "`etag.retries` of resource %s must be positive", rName)
+	}
+}
+
+func (e Etag) Allow(method string) bool {
+	return slices.Contains(e.Actions, strings.ToLower(method))
+}
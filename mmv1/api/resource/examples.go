@@ -192,6 +192,9 @@ type Examples struct {
 	// The reason to skip a test. For example, a link to a ticket explaining the issue that needs to be resolved before
 	// unskipping the test. If this is not empty, the test will be skipped.
 	TGCSkipTest string `yaml:"tgc_skip_test,omitempty"`
+
+	// Directives of an override file, eg: `$delete`. See MergeDirectives.
+	MergeDirectives MergeDirectives `yaml:",inline"`
 }
 
 // Set default value for fields
@@ -212,11 +215,14 @@ func (e *Examples) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (e *Examples) Validate(rName string) {
+func (e *Examples) Validate(rName, yamlPath string, diags *google.Diagnostics) {
 	if e.Name == "" {
-		log.Fatalf("Missing `name` for one example in resource %s", rName)
+		diags.Errorf(yamlPath, "", "Missing `name` for one example in resource %s", rName)
+	}
+	if !e.MergeDirectives.IsEmpty() {
+		diags.Errorf(yamlPath, "", "`$delete`, `$replace` and `$position` are only supported in override files, on an example that exists in the files before it")
 	}
-	e.ValidateExternalProviders()
+	e.ValidateExternalProviders(yamlPath, diags)
 }
 
 func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) {
@@ -235,7 +241,7 @@ func validateRegexForContents(r *regexp.Regexp, contents string, configPath stri
 	}
 }
 
-func (e *Examples) ValidateExternalProviders() {
+func (e *Examples) ValidateExternalProviders(yamlPath string, diags *google.Diagnostics) {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -251,7 +257,7 @@ func (e *Examples) ValidateExternalProviders() {
 	}
 
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		diags.Errorf(yamlPath, "", "Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
 }
 
@@ -0,0 +1,28 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Information about the provider functions generated for the id of this
+// resource. Two functions are generated from `id_format`: one building the
+// id from its fields, eg: `compute_instance_id(project, zone, name)`, and one
+// returning the fields of an id, self link or OP style resource name as an
+// object, eg: `parse_compute_instance_id(id)`.
+type IdFunctions struct {
+	// boolean of if the functions should be generated
+	Exclude bool
+
+	// [Optional] The version of the functions. Defaults to the min_version of
+	// the resource.
+	MinVersion string `yaml:"min_version"`
+}
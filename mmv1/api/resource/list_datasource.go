@@ -0,0 +1,32 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Information about the plural data source generated for this resource, eg:
+// google_pubsub_topics. The data source lists every resource under the
+// collection url of the resource and flattens each of them like the
+// resource's Read does.
+type ListDatasource struct {
+	// boolean of if the data source should be generated
+	Exclude bool
+
+	// If true, the data source has an optional filter argument that is passed
+	// to the list method unchanged. Only set it for APIs that accept a filter
+	// query parameter, as described by AIP-160.
+	Filter bool
+
+	// [Optional] The version of the data source. Defaults to the min_version
+	// of the resource.
+	MinVersion string `yaml:"min_version"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Information about the list resource generated for this resource. List
+// resources are used by `terraform query` to find existing resources and
+// generate the configuration needed to import them. The list resource lists
+// the collection url of the resource, and returns the identity and state of
+// every resource in it.
+//
+// Parameters of the collection url that are not set in the query are filled
+// from the sweeper: values of `sweeper.url_substitutions` are listed in turn,
+// and the parent field of `sweeper.parent` is filled by listing the parent
+// resource when it is in the same product.
+type ListResource struct {
+	// boolean of if the list resource should be generated
+	Exclude bool
+
+	// [Optional] The version of the list resource. Defaults to the
+	// min_version of the resource.
+	MinVersion string `yaml:"min_version"`
+}
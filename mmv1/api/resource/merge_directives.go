@@ -0,0 +1,48 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+// Directives of an override file controlling how an entry is merged into the
+// entry with the same name in the files before it. eg:
+//
+//	properties:
+//	  - name: 'labels'
+//	    $delete: true
+//	  - name: 'description'
+//	    $replace: ['required']
+//	    $position: 'first'
+//
+// `$delete` and `$position` apply to entries of lists, eg: properties and
+// examples. Use `exclude: true` to skip a whole resource. Directives are
+// applied and cleared while merging, so they are never set on the merged
+// objects.
+type MergeDirectives struct {
+	// Removes the entry with the same name from the list, eg: a property or
+	// an example.
+	Delete bool `yaml:"$delete,omitempty"`
+
+	// YAML keys of the fields set to the value of the override even if it is
+	// empty, eg: ['required', 'properties'] unsets `required` and replaces the
+	// nested properties instead of merging them.
+	Replace []string `yaml:"$replace,omitempty"`
+
+	// Moves the entry within its list. One of `first`, `last`,
+	// `before:<name>` or `after:<name>`.
+	Position string `yaml:"$position,omitempty"`
+}
+
+// Returns whether no directive is set.
+func (d MergeDirectives) IsEmpty() bool {
+	return !d.Delete && len(d.Replace) == 0 && d.Position == ""
+}
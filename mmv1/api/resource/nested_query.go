@@ -13,7 +13,7 @@
 
 package resource
 
-import "log"
+import "github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 
 // Metadata for resources that are nested within a parent resource, as
 // a list of resources or single object within the parent.
@@ -43,8 +43,8 @@ type NestedQuery struct {
 	ModifyByPatch bool `yaml:"modify_by_patch"`
 }
 
-func (q *NestedQuery) Validate(rName string) {
+func (q *NestedQuery) Validate(rName, yamlPath string, diags *google.Diagnostics) {
 	if len(q.Keys) == 0 {
-		log.Fatalf("Missing `keys` for `nested_query` in resource %s", rName)
+		diags.Errorf(yamlPath, "", "Missing `keys` for `nested_query` in resource %s", rName)
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The ways to wait for the update of a group of fields to finish.
+var UpdateMethodPolls = []string{"async", "operation", "none"}
+
+// Customizes the request sent for a group of fields with their own
+// `update_url`, for methods whose body isn't just the values of the fields.
+// e.g. setSettings, which takes {"settings": {...}} along with a fingerprint
+// read from the resource.
+type UpdateMethod struct {
+	// [Required] The `update_url` of the fields of the group.
+	UpdateUrl string `yaml:"update_url"`
+
+	// The `update_id` of the fields of the group, if they set one.
+	UpdateId string `yaml:"update_id,omitempty"`
+
+	// Key the values of the fields are nested under in the request body,
+	// along with the fingerprint. e.g. "settings" sends
+	// {"settings": {"tier": ..., "settingsVersion": ...}}
+	// The fingerprint is always nested, so bodies with the fingerprint next to
+	// the wrapper, e.g. setTags' {"tags": {...}, "fingerprint": ...}, aren't
+	// supported.
+	Wrapper string `yaml:"wrapper,omitempty"`
+
+	// Key the id of the resource is sent as, next to the values of the fields,
+	// e.g. name for commit methods taking the whole resource:
+	// {"schema": {"name": ..., "definition": ...}}
+	IdField string `yaml:"id_field,omitempty"`
+
+	// Values added to the top level of the request body as is, e.g.
+	// force: true. Only strings, booleans and numbers are supported.
+	StaticFields map[string]interface{} `yaml:"static_fields,omitempty"`
+
+	// Dot-separated path of the fingerprint in the read response, e.g.
+	// settings.settingsVersion. The fingerprint is sent as the
+	// `fingerprint_name` of the fields, which is also the default path.
+	FingerprintSource string `yaml:"fingerprint_source,omitempty"`
+
+	// How to wait for the update to finish. `async` follows the async config
+	// of the resource if it allows updates, `operation` waits for the
+	// operation returned by the method and `none` doesn't wait.
+	// Default: async
+	Poll string `yaml:"poll,omitempty"`
+}
+
+func (m *UpdateMethod) SetDefault() {
+	if m.Poll == "" {
+		m.Poll = "async"
+	}
+}
+
+func (m *UpdateMethod) Validate(rName, yamlPath string, diags *google.Diagnostics) {
+	if m.UpdateUrl == "" {
+		diags.Errorf(yamlPath, "", "Missing `update_url` for one of `update_methods` in resource %s", rName)
+	}
+	if m.Poll != "" && !slices.Contains(UpdateMethodPolls, m.Poll) {
+		diags.Errorf(yamlPath, "", "`poll` of update method %s in resource %s must be one of %v", m.UpdateUrl, rName, UpdateMethodPolls)
+	}
+	for k, v := range m.StaticFields {
+		switch v.(type) {
+		case string, bool, int, float64:
+		default:
+			diags.Errorf(yamlPath, "", "`static_fields.%s` of update method %s in resource %s must be a string, boolean or number", k, m.UpdateUrl, rName)
+		}
+	}
+}
+
+// Returns the Go literals of the static fields, keyed by name.
+func (m UpdateMethod) StaticFieldLiterals() map[string]string {
+	literals := make(map[string]string)
+	for k, v := range m.StaticFields {
+		switch v := v.(type) {
+		case string:
+			literals[k] = fmt.Sprintf("%q", v)
+		default:
+			literals[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	return literals
+}
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -503,3 +505,835 @@ func TestHasPostCreateComputedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestDatasourceFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description      string
+		datasource       resource.Datasource
+		idFormat         string
+		expectedRequired []string
+		expectedOptional []string
+	}{
+		{
+			description:      "fields default to the id format",
+			datasource:       resource.Datasource{},
+			idFormat:         "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+			expectedRequired: []string{"location", "name"},
+			expectedOptional: []string{"project"},
+		},
+		{
+			description:      "fields with a provider default are optional",
+			datasource:       resource.Datasource{},
+			idFormat:         "projects/{{project}}/regions/{{region}}/addresses/{{name}}",
+			expectedRequired: []string{"name"},
+			expectedOptional: []string{"project", "region"},
+		},
+		{
+			description:      "fields set in yaml are kept",
+			datasource:       resource.Datasource{RequiredFields: []string{"project", "name"}},
+			idFormat:         "projects/{{project}}/topics/{{name}}",
+			expectedRequired: []string{"project", "name"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Product{Name: "test"}
+			r := Resource{Name: "test", IdFormat: tc.idFormat, Datasource: &tc.datasource}
+			r.SetDefault(p)
+
+			if got := r.Datasource.RequiredFields; !reflect.DeepEqual(got, tc.expectedRequired) {
+				t.Errorf("expected required fields %v, got %v", tc.expectedRequired, got)
+			}
+			if got := r.Datasource.OptionalFields; !reflect.DeepEqual(got, tc.expectedOptional) {
+				t.Errorf("expected optional fields %v, got %v", tc.expectedOptional, got)
+			}
+		})
+	}
+}
+
+func TestListDatasource(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description       string
+		resource          Resource
+		expectedName      string
+		expectedAttribute string
+		expectedUri       string
+		expectedParams    []string
+	}{
+		{
+			description:       "collection url",
+			resource:          Resource{Name: "Topic", BaseUrl: "projects/{{project}}/topics"},
+			expectedName:      "google_test_topics",
+			expectedAttribute: "topics",
+			expectedUri:       "projects/{{project}}/topics",
+			expectedParams:    []string{"project"},
+		},
+		{
+			description:       "query parameters are dropped",
+			resource:          Resource{Name: "Instance", BaseUrl: "projects/{{project}}/locations/{{location}}/instances?instanceId={{name}}"},
+			expectedName:      "google_test_instances",
+			expectedAttribute: "instances",
+			expectedUri:       "projects/{{project}}/locations/{{location}}/instances",
+			expectedParams:    []string{"project", "location"},
+		},
+		{
+			description:       "zonal resources use aggregated lists",
+			resource:          Resource{Name: "Address", BaseUrl: "projects/{{project}}/zones/{{zone}}/addresses"},
+			expectedName:      "google_test_addresses",
+			expectedAttribute: "addresses",
+			expectedUri:       "projects/{{project}}/aggregated/addresses",
+			expectedParams:    []string{"project"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.resource.ProductMetadata = &Product{Name: "Test"}
+			if got := tc.resource.ListDatasourceTerraformName(); got != tc.expectedName {
+				t.Errorf("expected name %q, got %q", tc.expectedName, got)
+			}
+			if got := tc.resource.ListDatasourceAttribute(); got != tc.expectedAttribute {
+				t.Errorf("expected attribute %q, got %q", tc.expectedAttribute, got)
+			}
+			if got := tc.resource.ListUri(); got != tc.expectedUri {
+				t.Errorf("expected uri %q, got %q", tc.expectedUri, got)
+			}
+			if got := tc.resource.ListParams(); !reflect.DeepEqual(got, tc.expectedParams) {
+				t.Errorf("expected params %v, got %v", tc.expectedParams, got)
+			}
+		})
+	}
+}
+
+func TestListResource(t *testing.T) {
+	t.Parallel()
+
+	p := &Product{Name: "Test", Versions: []*product.Version{{Name: "ga"}}}
+	parent := &Resource{Name: "Cluster", BaseUrl: "projects/{{project}}/locations/{{location}}/clusters", ProductMetadata: p}
+	child := &Resource{
+		Name:              "NodePool",
+		BaseUrl:           "projects/{{project}}/locations/{{location}}/clusters/{{cluster}}/nodePools",
+		TargetVersionName: "ga",
+		ProductMetadata:   p,
+		Sweeper: resource.Sweeper{
+			URLSubstitutions: []map[string]string{
+				{"location": "us-central1", "region": "us-central1"},
+				{"location": "REGION"},
+				{"location": "global"},
+				{"location": "us-central1"},
+			},
+			Parent: &resource.ParentResource{ResourceType: "google_test_cluster", ParentField: "name", ChildField: "cluster"},
+		},
+	}
+	p.Objects = []*Resource{parent, child}
+
+	if got, expected := child.ListResourceDefaultValues("location"), []string{"us-central1", "global"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected default locations %v, got %v", expected, got)
+	}
+	if got := child.ListResourceDefaultValues("region"); got != nil {
+		t.Errorf("expected no default regions, got %v", got)
+	}
+	if got := child.ListResourceParent(); got != parent {
+		t.Errorf("expected the parent resource, got %v", got)
+	}
+	for param, expected := range map[string]bool{"project": true, "location": true, "cluster": true} {
+		if got := child.ListResourceOptionalParam(param); got != expected {
+			t.Errorf("expected %s to be optional: %t, got %t", param, expected, got)
+		}
+	}
+
+	// The parent cannot be listed if its list url needs a parameter the child
+	// list url does not have.
+	parent.BaseUrl = "folders/{{folder}}/clusters"
+	if got := child.ListResourceParent(); got != nil {
+		t.Errorf("expected no parent resource, got %v", got.Name)
+	}
+	if child.ListResourceOptionalParam("cluster") {
+		t.Errorf("expected cluster to be required without a parent")
+	}
+}
+
+func TestHasIdentity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    Resource
+		expected    bool
+	}{
+		{
+			description: "string fields",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/instances",
+				IdFormat: "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+				Parameters: []*Type{
+					{Name: "location", Type: "String"},
+				},
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+			},
+			expected: true,
+		},
+		{
+			description: "integer field",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/instances",
+				IdFormat: "projects/{{project}}/instances/{{instance_id}}",
+				Properties: []*Type{
+					{Name: "instanceId", Type: "Integer"},
+				},
+			},
+			expected: false,
+		},
+		{
+			description: "field missing from the resource",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/instances",
+				IdFormat: "projects/{{project}}/instances/{{name}}",
+			},
+			expected: false,
+		},
+		{
+			description: "custom import",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/instances",
+				IdFormat: "projects/{{project}}/instances/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				CustomCode: resource.CustomCode{
+					CustomImport: "templates/terraform/custom_import/self_link_as_name_set_project.go.tmpl",
+				},
+			},
+			expected: false,
+		},
+		{
+			description: "excluded identity",
+			resource: Resource{
+				BaseUrl:  "projects/{{project}}/instances",
+				IdFormat: "projects/{{project}}/instances/{{name}}",
+				Properties: []*Type{
+					{Name: "name", Type: "String"},
+				},
+				ExcludeIdentity: true,
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.resource.HasIdentity(); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEphemeral(t *testing.T) {
+	t.Parallel()
+
+	payload := func() []*Type {
+		return []*Type{
+			{
+				Name: "payload",
+				Type: "NestedObject",
+				Properties: []*Type{
+					{Name: "data", Type: "String", Sensitive: true},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		description          string
+		ephemeral            Ephemeral
+		expectedVerb         string
+		expectedParams       []string
+		expectedName         string
+		expectedFunctionName string
+		expectedError        string
+	}{
+		{
+			description:          "defaults",
+			ephemeral:            Ephemeral{Url: "{{name}}:access", Properties: payload()},
+			expectedVerb:         "GET",
+			expectedParams:       []string{"name"},
+			expectedName:         "google_secret_manager_secret_version",
+			expectedFunctionName: "NewSecretManagerSecretVersionEphemeralResource",
+		},
+		{
+			description:          "name and verb set in yaml",
+			ephemeral:            Ephemeral{Name: "AccessedSecretVersion", Url: "{{name}}:access", Verb: "POST", Properties: payload()},
+			expectedVerb:         "POST",
+			expectedParams:       []string{"name"},
+			expectedName:         "google_secret_manager_accessed_secret_version",
+			expectedFunctionName: "NewSecretManagerAccessedSecretVersionEphemeralResource",
+		},
+		{
+			description:   "missing properties",
+			ephemeral:     Ephemeral{Url: "{{name}}:access"},
+			expectedError: "Missing `properties`",
+		},
+		{
+			description: "field already a parameter of the url",
+			ephemeral: Ephemeral{
+				Url:        "{{name}}:access",
+				Properties: []*Type{{Name: "name", Type: "String"}},
+			},
+			expectedError: "already a parameter of the url",
+		},
+		{
+			description: "field with a custom flattener",
+			ephemeral: Ephemeral{
+				Url:        "{{name}}:access",
+				Properties: []*Type{{Name: "data", Type: "String", CustomFlatten: "templates/terraform/custom_flatten/data.go.tmpl"}},
+			},
+			expectedError: "does not support custom expanders and flatteners",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Product{Name: "SecretManager", Versions: []*product.Version{{Name: "ga"}}}
+			r := &Resource{
+				Name:              "SecretVersion",
+				BaseUrl:           "{{name}}",
+				TargetVersionName: "ga",
+				Properties: []*Type{
+					{Name: "name", Type: "String", Output: true},
+				},
+				Ephemeral: &tc.ephemeral,
+			}
+			p.Objects = []*Resource{r}
+			r.SetDefault(p)
+
+			diags := google.NewDiagnostics()
+			r.Ephemeral.Validate(r, diags)
+			if !matchesDiagnostics(diags, tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, diags.All())
+			}
+			if tc.expectedError != "" {
+				return
+			}
+
+			if got := r.Ephemeral.Verb; got != tc.expectedVerb {
+				t.Errorf("expected verb %q, got %q", tc.expectedVerb, got)
+			}
+			if got := r.EphemeralParams(); !reflect.DeepEqual(got, tc.expectedParams) {
+				t.Errorf("expected params %v, got %v", tc.expectedParams, got)
+			}
+			if got := r.EphemeralTerraformName(); got != tc.expectedName {
+				t.Errorf("expected terraform name %q, got %q", tc.expectedName, got)
+			}
+			if got := r.EphemeralFunctionName(); got != tc.expectedFunctionName {
+				t.Errorf("expected function name %q, got %q", tc.expectedFunctionName, got)
+			}
+			data := r.Ephemeral.Properties[0].Properties[0]
+			if got, expected := data.GetPrefix(), "EphemeralSecretManagerSecretVersionPayload"; got != expected {
+				t.Errorf("expected prefix %q, got %q", expected, got)
+			}
+			if !data.Output {
+				t.Errorf("expected nested ephemeral properties to be output")
+			}
+		})
+	}
+}
+
+func TestIdFunctions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description       string
+		idFormat          string
+		idFunctions       resource.IdFunctions
+		expectedGenerated bool
+		expectedName      string
+		expectedParseName string
+		expectedError     string
+	}{
+		{
+			description:       "defaults",
+			expectedGenerated: true,
+			expectedName:      "pubsub_topic_id",
+			expectedParseName: "parse_pubsub_topic_id",
+		},
+		{
+			description:       "min_version newer than the target version",
+			idFunctions:       resource.IdFunctions{MinVersion: "beta"},
+			expectedGenerated: false,
+		},
+		{
+			description:       "excluded",
+			idFunctions:       resource.IdFunctions{Exclude: true},
+			expectedGenerated: false,
+		},
+		{
+			description:       "id format without fields",
+			idFormat:          "topics",
+			expectedGenerated: true,
+			expectedError:     "requires fields in the id format",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Product{Name: "Pubsub", Versions: []*product.Version{{Name: "ga"}}}
+			r := &Resource{
+				Name:              "Topic",
+				BaseUrl:           "projects/{{project}}/topics",
+				IdFormat:          tc.idFormat,
+				TargetVersionName: "ga",
+				IdFunctions:       &tc.idFunctions,
+			}
+			p.Objects = []*Resource{r}
+			r.SetDefault(p)
+
+			diags := google.NewDiagnostics()
+			r.validateIdFunctions(diags)
+			if !matchesDiagnostics(diags, tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, diags.All())
+			}
+
+			if got := r.GeneratesIdFunctions(); got != tc.expectedGenerated {
+				t.Errorf("expected id functions generated: %t, got %t", tc.expectedGenerated, got)
+			}
+			if tc.expectedName == "" {
+				return
+			}
+			if got := r.IdFunctionName(); got != tc.expectedName {
+				t.Errorf("expected function name %q, got %q", tc.expectedName, got)
+			}
+			if got := r.ParseIdFunctionName(); got != tc.expectedParseName {
+				t.Errorf("expected function name %q, got %q", tc.expectedParseName, got)
+			}
+		})
+	}
+}
+
+func TestUpdateMethods(t *testing.T) {
+	t.Parallel()
+
+	resizeUrl := "projects/{{project}}/disks/{{name}}/resize"
+
+	cases := []struct {
+		description      string
+		method           resource.UpdateMethod
+		async            *Async
+		customUpdate     string
+		fingerprintName  string
+		expectedPoll     string
+		expectedLiterals map[string]string
+		expectedError    string
+	}{
+		{
+			description: "static fields",
+			method: resource.UpdateMethod{
+				UpdateUrl: resizeUrl,
+				StaticFields: map[string]interface{}{
+					"force":  true,
+					"reason": "terraform",
+					"count":  2,
+				},
+			},
+			expectedPoll:     "async",
+			expectedLiterals: map[string]string{"force": "true", "reason": `"terraform"`, "count": "2"},
+		},
+		{
+			description:      "poll none",
+			method:           resource.UpdateMethod{UpdateUrl: resizeUrl, Poll: "none"},
+			expectedPoll:     "none",
+			expectedLiterals: map[string]string{},
+		},
+		{
+			description:      "poll operation with an operation",
+			method:           resource.UpdateMethod{UpdateUrl: resizeUrl, Poll: "operation"},
+			async:            NewAsync(),
+			expectedPoll:     "operation",
+			expectedLiterals: map[string]string{},
+		},
+		{
+			description:      "fingerprint_source with fingerprint_name",
+			method:           resource.UpdateMethod{UpdateUrl: resizeUrl, FingerprintSource: "settings.settingsVersion"},
+			fingerprintName:  "settingsFingerprint",
+			expectedPoll:     "async",
+			expectedLiterals: map[string]string{},
+		},
+		{
+			description:   "poll operation without an operation",
+			method:        resource.UpdateMethod{UpdateUrl: resizeUrl, Poll: "operation"},
+			expectedError: "requires an `OpAsync` async config",
+		},
+		{
+			description:   "unknown poll",
+			method:        resource.UpdateMethod{UpdateUrl: resizeUrl, Poll: "sometimes"},
+			expectedError: "must be one of",
+		},
+		{
+			description:   "update_url of no field",
+			method:        resource.UpdateMethod{UpdateUrl: "projects/{{project}}/disks/{{name}}/setLabels"},
+			expectedError: "doesn't match the `update_url` and `update_id` of any field",
+		},
+		{
+			description:   "update_id of no field",
+			method:        resource.UpdateMethod{UpdateUrl: resizeUrl, UpdateId: "resize"},
+			expectedError: "doesn't match the `update_url` and `update_id` of any field",
+		},
+		{
+			description:   "missing update_url",
+			method:        resource.UpdateMethod{},
+			expectedError: "Missing `update_url`",
+		},
+		{
+			description:   "fingerprint_source without fingerprint_name",
+			method:        resource.UpdateMethod{UpdateUrl: resizeUrl, FingerprintSource: "settings.settingsVersion"},
+			expectedError: "requires the `fingerprint_name` of its fields",
+		},
+		{
+			description: "static field of an unsupported type",
+			method: resource.UpdateMethod{
+				UpdateUrl:    resizeUrl,
+				StaticFields: map[string]interface{}{"labels": map[string]interface{}{"env": "test"}},
+			},
+			expectedError: "`static_fields.labels` of update method",
+		},
+		{
+			description:   "custom_update",
+			method:        resource.UpdateMethod{UpdateUrl: resizeUrl},
+			customUpdate:  "templates/terraform/custom_update/disk.go.tmpl",
+			expectedError: "are not used by resource Disk, as it sets `custom_update`",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			method := tc.method
+			r := &Resource{
+				Name:          "Disk",
+				Async:         tc.async,
+				UpdateMethods: []*resource.UpdateMethod{&method},
+				CustomCode:    resource.CustomCode{CustomUpdate: tc.customUpdate},
+				Properties: []*Type{
+					{Name: "sizeGb", Type: "Integer", UpdateUrl: resizeUrl, UpdateVerb: "POST", FingerprintName: tc.fingerprintName},
+					{Name: "description", Type: "String", UpdateUrl: "projects/{{project}}/disks/{{name}}", UpdateVerb: "PATCH"},
+				},
+			}
+			r.SetDefault(&Product{Name: "Compute", Versions: []*product.Version{{Name: "ga"}}})
+
+			diags := google.NewDiagnostics()
+			r.validateUpdateMethods(diags)
+			if !matchesDiagnostics(diags, tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, diags.All())
+			}
+			if tc.expectedError != "" {
+				return
+			}
+
+			groups := r.PropertiesByCustomUpdateGroups()
+			if len(groups) != 2 {
+				t.Fatalf("expected 2 update groups, got %d", len(groups))
+			}
+			for _, group := range groups {
+				var expected *resource.UpdateMethod
+				expectedPoll := "async"
+				if group.UpdateUrl == resizeUrl {
+					expected = &method
+					expectedPoll = tc.expectedPoll
+				}
+				if got := r.UpdateMethodForGroup(group); got != expected {
+					t.Errorf("expected update method %v for %s, got %v", expected, group.UpdateUrl, got)
+				}
+				if got := r.UpdatePoll(group); got != expectedPoll {
+					t.Errorf("expected poll %q for %s, got %q", expectedPoll, group.UpdateUrl, got)
+				}
+			}
+
+			if got := method.StaticFieldLiterals(); !reflect.DeepEqual(got, tc.expectedLiterals) {
+				t.Errorf("expected static fields %v, got %v", tc.expectedLiterals, got)
+			}
+		})
+	}
+}
+
+func TestEtag(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description     string
+		etag            resource.Etag
+		etagType        string
+		etagOutput      bool
+		nestedQuery     *resource.NestedQuery
+		customCode      resource.CustomCode
+		expectedRetries int
+		expectedUpdate  bool
+		expectedDelete  bool
+		expectedError   string
+	}{
+		{
+			description:     "defaults",
+			etagType:        "String",
+			etagOutput:      true,
+			expectedRetries: 3,
+			expectedUpdate:  true,
+			expectedDelete:  true,
+		},
+		{
+			description:     "updates only",
+			etag:            resource.Etag{Actions: []string{"update"}, Retries: 5},
+			etagType:        "String",
+			etagOutput:      true,
+			expectedRetries: 5,
+			expectedUpdate:  true,
+		},
+		{
+			description:     "fingerprint field",
+			etagType:        "Fingerprint",
+			etagOutput:      true,
+			expectedRetries: 3,
+			expectedUpdate:  true,
+			expectedDelete:  true,
+		},
+		{
+			description:     "custom_update with deletes only",
+			etag:            resource.Etag{Actions: []string{"delete"}},
+			etagType:        "String",
+			etagOutput:      true,
+			customCode:      resource.CustomCode{CustomUpdate: "templates/terraform/custom_update/pipeline.go.tmpl"},
+			expectedRetries: 3,
+			expectedDelete:  true,
+		},
+		{
+			description:   "field that isn't output",
+			etagType:      "String",
+			expectedError: "requires an output String field named etag",
+		},
+		{
+			description:   "field that isn't a string",
+			etagType:      "Integer",
+			etagOutput:    true,
+			expectedError: "requires an output String field named etag",
+		},
+		{
+			description:   "missing field",
+			etag:          resource.Etag{Field: "version"},
+			etagType:      "String",
+			etagOutput:    true,
+			expectedError: "requires an output String field named version",
+		},
+		{
+			description:   "unknown action",
+			etag:          resource.Etag{Actions: []string{"create"}},
+			etagType:      "String",
+			etagOutput:    true,
+			expectedError: "must be update or delete, got create",
+		},
+		{
+			description:   "negative retries",
+			etag:          resource.Etag{Retries: -1},
+			etagType:      "String",
+			etagOutput:    true,
+			expectedError: "`etag.retries` of resource Pipeline must be positive",
+		},
+		{
+			description:   "nested_query",
+			etagType:      "String",
+			etagOutput:    true,
+			nestedQuery:   &resource.NestedQuery{Keys: []string{"pipelines"}},
+			expectedError: "as it sets `nested_query`",
+		},
+		{
+			description:   "custom_update",
+			etagType:      "String",
+			etagOutput:    true,
+			customCode:    resource.CustomCode{CustomUpdate: "templates/terraform/custom_update/pipeline.go.tmpl"},
+			expectedError: "isn't sent along updates of resource Pipeline, as it sets `custom_update`",
+		},
+		{
+			description:   "custom_delete",
+			etagType:      "String",
+			etagOutput:    true,
+			customCode:    resource.CustomCode{CustomDelete: "templates/terraform/custom_delete/pipeline.go.tmpl"},
+			expectedError: "isn't sent along deletes of resource Pipeline, as it sets `custom_delete`",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			etag := tc.etag
+			r := &Resource{
+				Name:        "Pipeline",
+				BaseUrl:     "projects/{{project}}/locations/{{location}}/pipelines",
+				Etag:        &etag,
+				NestedQuery: tc.nestedQuery,
+				CustomCode:  tc.customCode,
+				Properties: []*Type{
+					{Name: "displayName", Type: "String"},
+					{Name: "etag", Type: tc.etagType, Output: tc.etagOutput},
+				},
+			}
+			r.SetDefault(&Product{Name: "Eventarc", Versions: []*product.Version{{Name: "ga"}}})
+
+			diags := google.NewDiagnostics()
+			r.validateEtag(diags)
+			if !matchesDiagnostics(diags, tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, diags.All())
+			}
+			if tc.expectedError != "" {
+				return
+			}
+
+			if got := r.Etag.Retries; got != tc.expectedRetries {
+				t.Errorf("expected %d retries, got %d", tc.expectedRetries, got)
+			}
+			if got := r.EtagProperty(); got == nil || got.Name != "etag" {
+				t.Errorf("expected the etag property, got %v", got)
+			}
+			if got := r.SendsEtag("update"); got != tc.expectedUpdate {
+				t.Errorf("expected the etag sent along updates: %t, got %t", tc.expectedUpdate, got)
+			}
+			if got := r.SendsEtag("delete"); got != tc.expectedDelete {
+				t.Errorf("expected the etag sent along deletes: %t, got %t", tc.expectedDelete, got)
+			}
+		})
+	}
+}
+
+func TestBatching(t *testing.T) {
+	t.Parallel()
+
+	batchUrl := "projects/{{project}}/databases/{{database}}/documents:batchWrite"
+
+	cases := []struct {
+		description      string
+		batching         resource.Batching
+		nestedQuery      *resource.NestedQuery
+		customCreate     string
+		expectedBatchKey string
+		expectedError    string
+	}{
+		{
+			description:      "default batch_key",
+			batching:         resource.Batching{BatchUrl: batchUrl, RequestField: "writes"},
+			expectedBatchKey: batchUrl,
+		},
+		{
+			description:      "batch_key set",
+			batching:         resource.Batching{BatchUrl: batchUrl, BatchKey: "{{project}}", RequestField: "writes"},
+			expectedBatchKey: "{{project}}",
+		},
+		{
+			description:      "status_field",
+			batching:         resource.Batching{BatchUrl: batchUrl, RequestField: "writes", ResponseField: "writeResults", StatusField: "status"},
+			expectedBatchKey: batchUrl,
+		},
+		{
+			description:      "error_field",
+			batching:         resource.Batching{BatchUrl: batchUrl, RequestField: "writes", ResponseField: "writeResults", ErrorField: "error"},
+			expectedBatchKey: batchUrl,
+		},
+		{
+			description:   "missing batch_url",
+			batching:      resource.Batching{RequestField: "writes"},
+			expectedError: "Missing `batching.batch_url`",
+		},
+		{
+			description:   "missing request_field",
+			batching:      resource.Batching{BatchUrl: batchUrl},
+			expectedError: "Missing `batching.request_field`",
+		},
+		{
+			description:   "error_field without response_field",
+			batching:      resource.Batching{BatchUrl: batchUrl, RequestField: "writes", ErrorField: "error"},
+			expectedError: "`batching.error_field` requires `batching.response_field`",
+		},
+		{
+			description:   "error_field and status_field",
+			batching:      resource.Batching{BatchUrl: batchUrl, RequestField: "writes", ResponseField: "writeResults", ErrorField: "error", StatusField: "status"},
+			expectedError: "Only one of `batching.error_field` and `batching.status_field`",
+		},
+		{
+			description:   "custom_create",
+			batching:      resource.Batching{BatchUrl: batchUrl, RequestField: "writes"},
+			customCreate:  "templates/terraform/custom_create/firestore_document.go.tmpl",
+			expectedError: "as it sets `custom_create`",
+		},
+		{
+			description:   "nested_query modify_by_patch",
+			batching:      resource.Batching{BatchUrl: batchUrl, RequestField: "writes"},
+			nestedQuery:   &resource.NestedQuery{Keys: []string{"documents"}, ModifyByPatch: true},
+			expectedError: "as it sets `nested_query.modify_by_patch`",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			batching := tc.batching
+			r := &Resource{
+				Name:        "Document",
+				BaseUrl:     "projects/{{project}}/databases/{{database}}/documents/{{collection}}",
+				Batching:    &batching,
+				NestedQuery: tc.nestedQuery,
+				CustomCode:  resource.CustomCode{CustomCreate: tc.customCreate},
+			}
+			r.SetDefault(&Product{Name: "Firestore", Versions: []*product.Version{{Name: "ga"}}})
+
+			diags := google.NewDiagnostics()
+			r.validateBatching(diags)
+			if !matchesDiagnostics(diags, tc.expectedError) {
+				t.Fatalf("expected error %q, got %v", tc.expectedError, diags.All())
+			}
+			if tc.expectedError != "" {
+				return
+			}
+
+			if got := r.Batching.BatchKey; got != tc.expectedBatchKey {
+				t.Errorf("expected batch key %q, got %q", tc.expectedBatchKey, got)
+			}
+		})
+	}
+}
+
+// Returns whether diags has an error containing message, or no errors if
+// message is empty.
+func matchesDiagnostics(diags *google.Diagnostics, message string) bool {
+	if message == "" {
+		return !diags.HasErrors()
+	}
+	for _, d := range diags.All() {
+		if d.IsError() && strings.Contains(d.Message, message) {
+			return true
+		}
+	}
+	return false
+}
@@ -313,6 +313,10 @@ type Type struct {
 
 	// If true, we will include the empty value of this attribute in CAI asset.
 	IncludeEmptyValueInCai bool `yaml:"include_empty_value_in_cai,omitempty"`
+
+	// Directives of an override file, eg: `$delete`. See
+	// resource.MergeDirectives.
+	MergeDirectives resource.MergeDirectives `yaml:",inline"`
 }
 
 const MAX_NAME = 20
@@ -370,37 +374,44 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
-func (t *Type) Validate(rName string) {
+func (t *Type) Validate(rName string, diags *google.Diagnostics) {
+	yamlPath := t.sourceYamlFile()
+	lineage := t.Lineage()
+
 	if t.Name == "" {
-		log.Fatalf("Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+		diags.Errorf(yamlPath, lineage, "Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+	}
+
+	if !t.MergeDirectives.IsEmpty() {
+		diags.Errorf(yamlPath, lineage, "`$delete`, `$replace` and `$position` are only supported in override files, on a property that exists in the files before it")
 	}
 
 	if t.Output && t.Required {
-		log.Fatalf("Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
+		diags.Errorf(yamlPath, lineage, "Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		log.Fatalf("'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
+		diags.Errorf(yamlPath, lineage, "'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
 	}
 
 	if t.WriteOnly && (t.DefaultFromApi || t.Output) {
-		log.Fatalf("Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
+		diags.Errorf(yamlPath, lineage, "Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
 	}
 
 	if t.WriteOnly && t.Sensitive {
-		log.Fatalf("Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
+		diags.Errorf(yamlPath, lineage, "Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
 	}
 
-	t.validateLabelsField()
+	t.validateLabelsField(diags)
 
 	switch {
 	case t.IsA("Array"):
-		t.ItemType.Validate(rName)
+		t.ItemType.Validate(rName, diags)
 	case t.IsA("Map"):
-		t.ValueType.Validate(rName)
+		t.ValueType.Validate(rName, diags)
 	case t.IsA("NestedObject"):
 		for _, p := range t.Properties {
-			p.Validate(rName)
+			p.Validate(rName, diags)
 		}
 	default:
 	}
@@ -805,6 +816,62 @@ func (t Type) TFType(s string) string {
 	return "schema.TypeString"
 }
 
+// Returns the plugin framework type of the field, eg: String for
+// types.String, or an empty string if the field has no plugin framework
+// representation yet.
+func (t Type) FrameworkType() string {
+	switch {
+	case t.IsA("String") || t.IsA("Enum") || t.IsA("Time") || t.IsA("ResourceRef") || t.IsA("Fingerprint"):
+		return "String"
+	case t.IsA("Boolean"):
+		return "Bool"
+	case t.IsA("Integer"):
+		return "Int64"
+	case t.IsA("Double"):
+		return "Float64"
+	case t.IsA("NestedObject"):
+		return "Object"
+	case t.IsA("KeyValuePairs"):
+		return "Map"
+	case t.IsA("Array") && t.ItemType != nil:
+		if t.ItemType.IsA("NestedObject") || slices.Contains([]string{"String", "Bool", "Int64", "Float64"}, t.ItemType.FrameworkType()) {
+			return "List"
+		}
+	}
+	return ""
+}
+
+// Returns true if the field or one of its parents is an output field, so it
+// is only set by the API.
+func (t Type) IsOutputOnly() bool {
+	for p := &t; p != nil; p = p.Parent() {
+		if p.Output {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the Go expression of the plugin framework attr.Type of the field,
+// eg: types.ListType{ElemType: types.StringType}
+func (t Type) FrameworkAttrType() string {
+	switch t.FrameworkType() {
+	case "Object":
+		var attrTypes []string
+		for _, p := range t.UserProperties() {
+			attrTypes = append(attrTypes, fmt.Sprintf("%q: %s,\n", google.Underscore(p.Name), p.FrameworkAttrType()))
+		}
+		return fmt.Sprintf("types.ObjectType{AttrTypes: map[string]attr.Type{\n%s}}", strings.Join(attrTypes, ""))
+	case "List":
+		return fmt.Sprintf("types.ListType{ElemType: %s}", t.ItemType.FrameworkAttrType())
+	case "Map":
+		return "types.MapType{ElemType: types.StringType}"
+	case "":
+		return ""
+	}
+	return fmt.Sprintf("types.%sType", t.FrameworkType())
+}
+
 // TODO rewrite: validation
 // // Represents an enum, and store is valid values
 // class Enum < Primitive
@@ -987,6 +1054,12 @@ func propertyWithUpdateUrl(updateUrl string) func(*Type) {
 	}
 }
 
+func propertyWithFingerprintName(fingerprintName string) func(*Type) {
+	return func(p *Type) {
+		p.FingerprintName = fingerprintName
+	}
+}
+
 func propertyWithImmutable(immutable bool) func(*Type) {
 	return func(p *Type) {
 		p.Immutable = immutable
@@ -1005,7 +1078,15 @@ func propertyWithIgnoreWrite(ignoreWrite bool) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+// The YAML file the property was loaded from, used to attribute diagnostics.
+func (t *Type) sourceYamlFile() string {
+	if t.ResourceMetadata == nil {
+		return ""
+	}
+	return t.ResourceMetadata.SourceYamlFile
+}
+
+func (t *Type) validateLabelsField(diags *google.Diagnostics) {
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := t.Lineage()
@@ -1028,20 +1109,20 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			diags.Errorf(t.sourceYamlFile(), lineage, "Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		diags.Errorf(t.sourceYamlFile(), lineage, "Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			diags.Errorf(t.sourceYamlFile(), lineage, "Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		diags.Errorf(t.sourceYamlFile(), lineage, "Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 }
 
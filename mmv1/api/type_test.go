@@ -425,3 +425,71 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestFrameworkType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    string
+	}{
+		{
+			description: "string",
+			obj:         Type{Name: "foo", Type: "String"},
+			expected:    "String",
+		},
+		{
+			description: "enum",
+			obj:         Type{Name: "foo", Type: "Enum"},
+			expected:    "String",
+		},
+		{
+			description: "integer",
+			obj:         Type{Name: "foo", Type: "Integer"},
+			expected:    "Int64",
+		},
+		{
+			description: "nested object",
+			obj:         Type{Name: "foo", Type: "NestedObject"},
+			expected:    "Object",
+		},
+		{
+			description: "array of strings",
+			obj:         Type{Name: "foo", Type: "Array", ItemType: &Type{Type: "String"}},
+			expected:    "List",
+		},
+		{
+			description: "array of nested objects",
+			obj:         Type{Name: "foo", Type: "Array", ItemType: &Type{Type: "NestedObject"}},
+			expected:    "List",
+		},
+		{
+			description: "array of arrays",
+			obj:         Type{Name: "foo", Type: "Array", ItemType: &Type{Type: "Array", ItemType: &Type{Type: "String"}}},
+			expected:    "",
+		},
+		{
+			description: "key value pairs",
+			obj:         Type{Name: "foo", Type: "KeyValuePairs"},
+			expected:    "Map",
+		},
+		{
+			description: "labels",
+			obj:         Type{Name: "foo", Type: "KeyValueLabels"},
+			expected:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.FrameworkType(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
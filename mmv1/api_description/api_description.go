@@ -0,0 +1,57 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api_description holds the helpers shared by the tools reading the
+// OpenAPI or discovery description of an API: the YAML generators and drift.
+package api_description
+
+import (
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Returns the MMv1 name of a path parameter. Google APIs name them after the
+// collection, eg: projectsId, locationsId and accessPoliciesId, but MMv1 has
+// standardized on project, location and access_policy.
+func PathParamName(name string) string {
+	if collection, ok := strings.CutSuffix(name, "Id"); ok && strings.HasSuffix(collection, "s") {
+		name = google.Singular(collection)
+	}
+	return google.Underscore(name)
+}
+
+// Writes field as a string holding JSON, for values that cannot be described
+// by a schema.
+func WriteJsonString(field *api.Type, description string) {
+	field.Type = "String"
+	field.Description = strings.TrimSpace(description + "\nA JSON-encoded string.")
+	field.StateFunc = "func(v interface{}) string { s, _ := structure.NormalizeJsonString(v); return s }"
+	field.CustomExpand = "templates/terraform/custom_expand/json_schema.tmpl"
+	field.CustomFlatten = "templates/terraform/custom_flatten/json_schema.tmpl"
+	field.Validation.Function = "validation.StringIsJSON"
+}
+
+// Returns enum without the *_UNSPECIFIED value, which resources may or may
+// not list.
+func EnumValues(enum []string) []string {
+	var values []string
+	for _, v := range enum {
+		if strings.HasSuffix(v, "_UNSPECIFIED") {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
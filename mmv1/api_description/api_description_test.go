@@ -0,0 +1,59 @@
+package api_description
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestPathParamName(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"projectsId":       "project",
+		"locationsId":      "location",
+		"backupPlansId":    "backup_plan",
+		"policiesId":       "policy",
+		"accessPoliciesId": "access_policy",
+		"addressesId":      "address",
+		"indicesId":        "index",
+		"instance":         "instance",
+		"resourcePolicyId": "resource_policy_id",
+	}
+	for name, want := range cases {
+		if got := PathParamName(name); got != want {
+			t.Errorf("PathParamName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWriteJsonString(t *testing.T) {
+	t.Parallel()
+
+	var field api.Type
+	WriteJsonString(&field, "Free-form settings.")
+	if field.Type != "String" || field.Validation.Function != "validation.StringIsJSON" {
+		t.Errorf("expected a String field validated as JSON, got %s and %q", field.Type, field.Validation.Function)
+	}
+	if want := "Free-form settings.\nA JSON-encoded string."; field.Description != want {
+		t.Errorf("expected description %q, got %q", want, field.Description)
+	}
+
+	WriteJsonString(&field, "")
+	if want := "A JSON-encoded string."; field.Description != want {
+		t.Errorf("expected description %q for an undescribed field, got %q", want, field.Description)
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	t.Parallel()
+
+	got := EnumValues([]string{"STATE_UNSPECIFIED", "READY", "DELETING"})
+	if want := []string{"READY", "DELETING"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := EnumValues([]string{"TYPE_UNSPECIFIED"}); got != nil {
+		t.Errorf("expected no values, got %v", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery_generate
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// The subset of a Google API Discovery document used to generate MMv1 YAML.
+// See https://developers.google.com/discovery/v1/reference/apis
+type Document struct {
+	// Always v1 for the current format
+	DiscoveryVersion string `json:"discoveryVersion"`
+
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Title       string `json:"title"`
+	RootUrl     string `json:"rootUrl"`
+	ServicePath string `json:"servicePath"`
+
+	Auth struct {
+		Oauth2 struct {
+			Scopes map[string]struct {
+				Description string `json:"description"`
+			} `json:"scopes"`
+		} `json:"oauth2"`
+	} `json:"auth"`
+
+	Schemas map[string]*Schema `json:"schemas"`
+
+	Resources map[string]*Resource `json:"resources"`
+}
+
+type Schema struct {
+	Id          string `json:"id"`
+	Ref         string `json:"$ref"`
+	Type        string `json:"type"`
+	Format      string `json:"format"`
+	Description string `json:"description"`
+	ReadOnly    bool   `json:"readOnly"`
+
+	Enum             []string `json:"enum"`
+	EnumDescriptions []string `json:"enumDescriptions"`
+
+	Properties           map[string]*Schema `json:"properties"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+
+	// Compute lists the methods a field is required for, eg:
+	// "compute.addresses.insert"
+	Annotations struct {
+		Required []string `json:"required"`
+	} `json:"annotations"`
+}
+
+// A REST collection. Collections nest, eg: projects.locations.instances
+type Resource struct {
+	Methods   map[string]*Method   `json:"methods"`
+	Resources map[string]*Resource `json:"resources"`
+}
+
+type Method struct {
+	Id          string `json:"id"`
+	Path        string `json:"path"`
+	FlatPath    string `json:"flatPath"`
+	HttpMethod  string `json:"httpMethod"`
+	Description string `json:"description"`
+
+	Parameters     map[string]*Parameter `json:"parameters"`
+	ParameterOrder []string              `json:"parameterOrder"`
+
+	Request  *Schema `json:"request"`
+	Response *Schema `json:"response"`
+}
+
+type Parameter struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	Pattern     string `json:"pattern"`
+	Required    bool   `json:"required"`
+}
+
+func ReadDocument(filePath string) (*Document, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Returns the schema a $ref points to, or s itself if it is not a reference.
+func (d *Document) Resolve(s *Schema) *Schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	if resolved, ok := d.Schemas[s.Ref]; ok {
+		return resolved
+	}
+	return s
+}
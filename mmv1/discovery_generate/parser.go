@@ -0,0 +1,520 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Generates MMv1 YAML from Google API Discovery documents, as published in
+// google-api-go-client.
+
+package discovery_generate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api_description"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v2"
+)
+
+// License header written at the top of every generated file
+const headerPath = "openapi_generate/header.txt"
+
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+var versionPrefixRegex = regexp.MustCompile(`^v\d[^/]*/`)
+
+var pathParamRegex = regexp.MustCompile(`\{\+?(\w+)\}`)
+
+type Parser struct {
+	Folder string
+	Output string
+}
+
+func NewDiscoveryParser(folder, output string) Parser {
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	return Parser{
+		Folder: path.Join(wd, folder),
+		Output: path.Join(wd, output),
+	}
+}
+
+func (parser Parser) Run() {
+	files, err := filepath.Glob(filepath.Join(parser.Folder, "*.discovery.json"))
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+	if len(files) == 0 {
+		log.Fatalf("No discovery documents found in %s", parser.Folder)
+	}
+
+	for _, file := range files {
+		parser.WriteYaml(file)
+	}
+}
+
+func (parser Parser) WriteYaml(filePath string) {
+	log.Printf("Reading from file path %s", filePath)
+
+	doc, err := ReadDocument(filePath)
+	if err != nil {
+		log.Fatalf("error reading discovery document %s: %v", filePath, err)
+	}
+
+	header, err := os.ReadFile(headerPath)
+	if err != nil {
+		log.Fatalf("error reading header %v", err)
+	}
+
+	productPath := filepath.Join(parser.Output, doc.Name)
+	if err := os.MkdirAll(productPath, os.ModePerm); err != nil {
+		log.Fatalf("error creating product output directory %v: %v", productPath, err)
+	}
+
+	// Disables line wrap for long strings
+	yaml.FutureLineWrap()
+
+	writeFile(filepath.Join(productPath, "product.yaml"), header, BuildProduct(doc))
+	log.Printf("Generated product %s/product.yaml", productPath)
+
+	for _, resource := range BuildResources(doc) {
+		resourcePath := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
+		writeFile(resourcePath, header, resource)
+		log.Printf("Generated resource %s", resourcePath)
+	}
+}
+
+func writeFile(filePath string, header []byte, obj any) {
+	bytes, err := yaml.Marshal(obj)
+	if err != nil {
+		log.Fatalf("error marshalling yaml %v: %v", filePath, err)
+	}
+	if err := os.WriteFile(filePath, append(header, bytes...), 0644); err != nil {
+		log.Fatalf("error writing %v: %v", filePath, err)
+	}
+}
+
+func BuildProduct(doc *Document) *api.Product {
+	apiProduct := &api.Product{}
+
+	// Standard titling is "Service Name API"
+	displayName := strings.TrimSuffix(doc.Title, " API")
+	apiProduct.Name = strings.ReplaceAll(displayName, " ", "")
+	apiProduct.DisplayName = displayName
+
+	apiVersion := &product.Version{
+		Name:    "ga",
+		BaseUrl: ProductBaseUrl(doc),
+	}
+	if strings.Contains(doc.Version, "alpha") || strings.Contains(doc.Version, "beta") {
+		apiVersion.Name = "beta"
+	}
+	apiProduct.Versions = []*product.Version{apiVersion}
+
+	if _, ok := doc.Auth.Oauth2.Scopes[cloudPlatformScope]; ok || len(doc.Auth.Oauth2.Scopes) == 0 {
+		apiProduct.Scopes = []string{cloudPlatformScope}
+	} else {
+		apiProduct.Scopes = slices.Sorted(maps.Keys(doc.Auth.Oauth2.Scopes))
+	}
+
+	return apiProduct
+}
+
+// Most APIs have an empty servicePath and start every method path with the
+// API version, which is moved into the product base url. Compute has the
+// version in its servicePath instead.
+func ProductBaseUrl(doc *Document) string {
+	if doc.ServicePath == "" {
+		return fmt.Sprintf("%s%s/", doc.RootUrl, doc.Version)
+	}
+	return doc.RootUrl + doc.ServicePath
+}
+
+// Builds a resource for every collection with a create or insert method,
+// sorted by name.
+func BuildResources(doc *Document) []*api.Resource {
+	var resources []*api.Resource
+	var walk func(collections map[string]*Resource)
+	walk = func(collections map[string]*Resource) {
+		for _, key := range slices.Sorted(maps.Keys(collections)) {
+			collection := collections[key]
+			if CreateMethod(collection) != nil {
+				resources = append(resources, buildResource(doc, key, collection))
+			}
+			walk(collection.Resources)
+		}
+	}
+	walk(doc.Resources)
+
+	slices.SortFunc(resources, func(a, b *api.Resource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return resources
+}
+
+func CreateMethod(collection *Resource) *Method {
+	if m, ok := collection.Methods["create"]; ok {
+		return m
+	}
+	return collection.Methods["insert"]
+}
+
+func buildResource(doc *Document, collectionKey string, collection *Resource) *api.Resource {
+	create := CreateMethod(collection)
+	get := collection.Methods["get"]
+	del := collection.Methods["delete"]
+
+	resource := &api.Resource{}
+	resource.Name = resourceName(collectionKey, create)
+	resource.Description = "Description"
+
+	resource.BaseUrl = CollectionUrl(create)
+	if create.HttpMethod != "POST" {
+		resource.CreateVerb = create.HttpMethod
+	}
+
+	resource.SelfLink = fmt.Sprintf("%s/{{name}}", resource.BaseUrl)
+	if get != nil {
+		resource.SelfLink = nameLastParam(methodUrl(get))
+	}
+	resource.IdFormat = resource.SelfLink
+	resource.ImportFormat = []string{resource.SelfLink}
+
+	// The id of the new resource is either a query parameter of the create
+	// method, part of the create url, or the name field of the request body.
+	idParam := idQueryParam(create, resource.Name)
+	nameInUrl := idParam != "" || create.HttpMethod != "POST"
+	switch {
+	case idParam != "":
+		resource.CreateUrl = fmt.Sprintf("%s?%s={{name}}", resource.BaseUrl, idParam)
+	case create.HttpMethod != "POST":
+		resource.CreateUrl = resource.SelfLink
+	}
+
+	switch {
+	case collection.Methods["patch"] != nil:
+		resource.UpdateVerb = "PATCH"
+		_, resource.UpdateMask = collection.Methods["patch"].Parameters["updateMask"]
+	case collection.Methods["update"] != nil:
+		resource.UpdateVerb = "PUT"
+	default:
+		resource.Immutable = true
+	}
+
+	if del == nil {
+		resource.ExcludeDelete = true
+	}
+
+	resource.Async = buildAsync(doc, map[string]*Method{
+		"create": create,
+		"delete": del,
+		"update": updateMethod(collection),
+	})
+	resource.AutogenAsync = resource.Async != nil
+
+	resource.Parameters = buildParameters(resource.BaseUrl, create, nameInUrl, idParam)
+
+	request := doc.Resolve(create.Request)
+	if request != nil {
+		seen := map[string]bool{request.Id: true}
+		for _, k := range slices.Sorted(maps.Keys(request.Properties)) {
+			if k == "name" && nameInUrl {
+				continue
+			}
+			p := writeType(doc, k, request.Properties[k], create.Id, seen)
+			if k == "name" {
+				p.Required = true
+				p.Immutable = true
+			}
+			resource.Properties = append(resource.Properties, &p)
+		}
+	}
+
+	example := r.Examples{}
+	example.Name = "name_of_example_file"
+	example.PrimaryResourceId = "example"
+	example.Vars = map[string]string{"resource_name": "test-resource"}
+	resource.Examples = []r.Examples{example}
+
+	// Write the status as an encoded string to flag when a YAML file has been
+	// copy and pasted without actually using this tool
+	resource.AutogenStatus = base64.StdEncoding.EncodeToString([]byte(resource.Name))
+
+	return resource
+}
+
+// Returns the url of the collection create adds a resource to, relative to
+// the product base url. Collections created with a PUT on the resource
+// itself, eg: pubsub.projects.topics.create, don't have a collection url to
+// POST to, so the parent of the resource url is used instead.
+func CollectionUrl(create *Method) string {
+	createUrl := methodUrl(create)
+	if create.HttpMethod == "POST" {
+		return createUrl
+	}
+	return createUrl[:strings.LastIndex(createUrl, "/")]
+}
+
+func updateMethod(collection *Resource) *Method {
+	if m, ok := collection.Methods["patch"]; ok {
+		return m
+	}
+	return collection.Methods["update"]
+}
+
+// Resources are named after the schema of the create request, falling back
+// to the singular of the collection name.
+func resourceName(collectionKey string, create *Method) string {
+	if create.Request != nil && create.Request.Ref != "" {
+		return create.Request.Ref
+	}
+	return google.Camelize(google.Singular(collectionKey), "upper")
+}
+
+// Returns the path of m relative to the product base url, with parameters
+// in MMv1 form, eg: projects/{{project}}/locations/{{location}}/instances
+func methodUrl(m *Method) string {
+	url := m.FlatPath
+	if url == "" {
+		url = expandReservedParams(m)
+	}
+	url = versionPrefixRegex.ReplaceAllString(url, "")
+	return pathParamRegex.ReplaceAllStringFunc(url, func(match string) string {
+		name := pathParamRegex.FindStringSubmatch(match)[1]
+		return fmt.Sprintf("{{%s}}", api_description.PathParamName(name))
+	})
+}
+
+// Expands {+parent} style parameters, which hold several path segments, using
+// the pattern of the parameter, eg: ^projects/[^/]+/topics/[^/]+$ becomes
+// projects/{projectsId}/topics/{topicsId}
+func expandReservedParams(m *Method) string {
+	return regexp.MustCompile(`\{\+(\w+)\}`).ReplaceAllStringFunc(m.Path, func(match string) string {
+		name := strings.Trim(match, "{+}")
+		param, ok := m.Parameters[name]
+		if !ok || param.Pattern == "" {
+			return fmt.Sprintf("{%s}", name)
+		}
+		segments := strings.Split(strings.Trim(param.Pattern, "^$"), "/")
+		for i, segment := range segments {
+			if segment == "[^/]+" && i > 0 {
+				segments[i] = fmt.Sprintf("{%sId}", segments[i-1])
+			}
+		}
+		return strings.Join(segments, "/")
+	})
+}
+
+// Replaces the last parameter of url with {{name}}.
+func nameLastParam(url string) string {
+	i := strings.LastIndex(url, "{{")
+	if i < 0 {
+		return url
+	}
+	return url[:i] + "{{name}}" + url[strings.Index(url[i:], "}}")+i+2:]
+}
+
+// Returns the query parameter holding the id of a new resource, eg:
+// instanceId for Instance.
+func idQueryParam(create *Method, resourceName string) string {
+	for _, name := range slices.Sorted(maps.Keys(create.Parameters)) {
+		param := create.Parameters[name]
+		if param.Location == "query" && strings.EqualFold(name, resourceName+"Id") {
+			return name
+		}
+	}
+	return ""
+}
+
+// Returns the async block for methods returning an Operation, or nil if none
+// of them do.
+func buildAsync(doc *Document, methods map[string]*Method) *api.Async {
+	var actions []string
+	var operation *Schema
+	for _, action := range []string{"create", "delete", "update"} {
+		m := methods[action]
+		if m == nil || m.Response == nil || !strings.HasSuffix(m.Response.Ref, "Operation") {
+			continue
+		}
+		actions = append(actions, action)
+		operation = doc.Resolve(m.Response)
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+
+	async := api.NewAsync()
+	async.Actions = actions
+	async.Operation.BaseUrl = "{{op_id}}"
+	// google.longrunning.Operation holds the resource in `response`. Compute
+	// operations only point to it through targetLink.
+	_, async.Result.ResourceInsideResponse = operation.Properties["response"]
+	return async
+}
+
+// Builds the url parameters of the resource from the parameters in its base
+// url. project is left out as MMv1 infers it.
+func buildParameters(baseUrl string, create *Method, nameInUrl bool, idParam string) []*api.Type {
+	descriptions := make(map[string]string)
+	for name, param := range create.Parameters {
+		descriptions[api_description.PathParamName(name)] = param.Description
+	}
+
+	var parameters []*api.Type
+	for _, match := range regexp.MustCompile(`\{\{(\w+)\}\}`).FindAllStringSubmatch(baseUrl, -1) {
+		name := match[1]
+		if name == "project" {
+			continue
+		}
+		parameters = append(parameters, urlParameter(google.Camelize(name, "lower"), descriptions[name]))
+	}
+
+	if nameInUrl {
+		description := "The name of the resource."
+		if param, ok := create.Parameters[idParam]; ok && param.Description != "" {
+			description = param.Description
+		}
+		parameters = append(parameters, urlParameter("name", description))
+	}
+	return parameters
+}
+
+func urlParameter(name, description string) *api.Type {
+	return &api.Type{
+		Name:         name,
+		Type:         "String",
+		Description:  cleanDescription(description),
+		Required:     true,
+		Immutable:    true,
+		UrlParamOnly: true,
+	}
+}
+
+// Builds the MMv1 type for a schema. seen holds the ids of the schemas being
+// built by the callers, so that recursive schemas are written as a JSON
+// string instead of recursing forever.
+func writeType(doc *Document, name string, s *Schema, createId string, seen map[string]bool) api.Type {
+	field := api.Type{Name: name}
+	description := s.Description
+
+	resolved := doc.Resolve(s)
+	if s.Ref != "" {
+		if seen[s.Ref] {
+			log.Printf("%s is recursive, writing it as a JSON string", name)
+			api_description.WriteJsonString(&field, cleanDescription(description))
+			return field
+		}
+		seen[s.Ref] = true
+		defer delete(seen, s.Ref)
+		if description == "" {
+			description = resolved.Description
+		}
+	}
+
+	switch resolved.Type {
+	case "string":
+		field.Type = "String"
+		if values := api_description.EnumValues(resolved.Enum); len(values) > 0 {
+			field.Type = "Enum"
+			field.EnumValues = values
+		}
+	case "integer":
+		field.Type = "Integer"
+	case "number":
+		field.Type = "Double"
+	case "boolean":
+		field.Type = "Boolean"
+	case "array":
+		field.Type = "Array"
+		item := writeType(doc, name, resolved.Items, createId, seen)
+		field.ItemType = &api.Type{
+			Type:          item.Type,
+			EnumValues:    item.EnumValues,
+			Properties:    item.Properties,
+			ItemType:      item.ItemType,
+			StateFunc:     item.StateFunc,
+			CustomExpand:  item.CustomExpand,
+			CustomFlatten: item.CustomFlatten,
+		}
+	case "object":
+		switch {
+		case name == "labels":
+			// Standard labels implementation
+			field.Type = "KeyValueLabels"
+		case resolved.AdditionalProperties != nil && doc.Resolve(resolved.AdditionalProperties).Type == "string":
+			field.Type = "KeyValuePairs"
+		case len(resolved.Properties) > 0:
+			field.Type = "NestedObject"
+			for _, k := range slices.Sorted(maps.Keys(resolved.Properties)) {
+				p := writeType(doc, k, resolved.Properties[k], createId, seen)
+				field.Properties = append(field.Properties, &p)
+			}
+		default:
+			// Free-form objects such as google.protobuf.Struct
+			api_description.WriteJsonString(&field, cleanDescription(description))
+			return field
+		}
+	default:
+		// "any", eg: google.protobuf.Value
+		api_description.WriteJsonString(&field, cleanDescription(description))
+		return field
+	}
+
+	field.Output = s.ReadOnly || resolved.ReadOnly || hasPrefix(description, "[Output Only]", "Output only.")
+	field.Required = !field.Output && (hasPrefix(description, "Required.") || slices.Contains(s.Annotations.Required, createId))
+	field.Immutable = hasPrefix(description, "Immutable.")
+	field.Description = cleanDescription(description)
+
+	return field
+}
+
+func hasPrefix(description string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(strings.TrimSpace(description), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Removes the markers that MMv1 expresses with fields, eg: "[Output Only]",
+// and trims whitespace from the ends of lines to force multiline formatting
+// for strings with newlines present.
+func cleanDescription(description string) string {
+	description = strings.TrimSpace(description)
+	for _, prefix := range []string{"[Output Only] ", "Output only. ", "Required. ", "Optional. ", "Immutable. "} {
+		description, _ = strings.CutPrefix(description, prefix)
+	}
+	if description == "" {
+		return "No description"
+	}
+
+	lines := strings.Split(description, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Trim(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
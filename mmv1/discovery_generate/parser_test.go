@@ -0,0 +1,282 @@
+package discovery_generate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// A long-running operation API, with paths relative to the version
+const lroDocument = `{
+  "name": "widget",
+  "version": "v1",
+  "title": "Widget API",
+  "rootUrl": "https://widget.googleapis.com/",
+  "servicePath": "",
+  "auth": {"oauth2": {"scopes": {"https://www.googleapis.com/auth/cloud-platform": {}}}},
+  "schemas": {
+    "Operation": {
+      "id": "Operation",
+      "type": "object",
+      "properties": {"name": {"type": "string"}, "done": {"type": "boolean"}, "response": {"type": "object", "additionalProperties": {"type": "any"}}}
+    },
+    "Widget": {
+      "id": "Widget",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "description": "Identifier. Name of the widget."},
+        "state": {"type": "string", "readOnly": true, "enum": ["STATE_UNSPECIFIED", "READY", "FAILED"]},
+        "tier": {"type": "string", "description": "Required. Tier of the widget.", "enum": ["TIER_UNSPECIFIED", "BASIC", "PREMIUM"]},
+        "zone": {"type": "string", "description": "Immutable. Zone of the widget."},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "tree": {"$ref": "Node"}
+      }
+    },
+    "Node": {
+      "id": "Node",
+      "type": "object",
+      "properties": {"value": {"type": "string"}, "children": {"type": "array", "items": {"$ref": "Node"}}}
+    }
+  },
+  "resources": {"projects": {"resources": {"locations": {"resources": {"widgets": {"methods": {
+    "create": {
+      "id": "widget.projects.locations.widgets.create",
+      "path": "v1/{+parent}/widgets",
+      "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/widgets",
+      "httpMethod": "POST",
+      "parameters": {
+        "parent": {"location": "path", "required": true, "pattern": "^projects/[^/]+/locations/[^/]+$"},
+        "widgetId": {"location": "query", "type": "string", "description": "Required. Id of the widget."}
+      },
+      "request": {"$ref": "Widget"},
+      "response": {"$ref": "Operation"}
+    },
+    "get": {
+      "id": "widget.projects.locations.widgets.get",
+      "path": "v1/{+name}",
+      "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/widgets/{widgetsId}",
+      "httpMethod": "GET",
+      "response": {"$ref": "Widget"}
+    },
+    "patch": {
+      "id": "widget.projects.locations.widgets.patch",
+      "path": "v1/{+name}",
+      "flatPath": "v1/projects/{projectsId}/locations/{locationsId}/widgets/{widgetsId}",
+      "httpMethod": "PATCH",
+      "parameters": {"updateMask": {"location": "query", "type": "string"}},
+      "request": {"$ref": "Widget"},
+      "response": {"$ref": "Operation"}
+    }
+  }}}}}}}
+}`
+
+// A compute style API, with the version in the service path and the name of
+// new resources in the request body
+const computeDocument = `{
+  "name": "gadget",
+  "version": "beta",
+  "title": "Gadget API",
+  "rootUrl": "https://gadget.googleapis.com/",
+  "servicePath": "gadget/beta/",
+  "auth": {"oauth2": {"scopes": {"https://www.googleapis.com/auth/gadget": {}}}},
+  "schemas": {
+    "Operation": {
+      "id": "Operation",
+      "type": "object",
+      "properties": {"name": {"type": "string"}, "targetLink": {"type": "string"}}
+    },
+    "Gadget": {
+      "id": "Gadget",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "annotations": {"required": ["gadget.gadgets.insert"]}},
+        "size": {"type": "integer", "annotations": {"required": ["gadget.gadgets.insert"]}},
+        "selfLink": {"type": "string", "description": "[Output Only] Server-defined URL for the resource."}
+      }
+    }
+  },
+  "resources": {"gadgets": {"methods": {
+    "insert": {
+      "id": "gadget.gadgets.insert",
+      "path": "projects/{project}/regions/{region}/gadgets",
+      "httpMethod": "POST",
+      "parameters": {"region": {"location": "path", "required": true, "description": "Name of the region."}},
+      "request": {"$ref": "Gadget"},
+      "response": {"$ref": "Operation"}
+    },
+    "get": {
+      "id": "gadget.gadgets.get",
+      "path": "projects/{project}/regions/{region}/gadgets/{gadget}",
+      "httpMethod": "GET",
+      "response": {"$ref": "Gadget"}
+    },
+    "delete": {
+      "id": "gadget.gadgets.delete",
+      "path": "projects/{project}/regions/{region}/gadgets/{gadget}",
+      "httpMethod": "DELETE",
+      "response": {"$ref": "Operation"}
+    }
+  }}}
+}`
+
+func loadTestDocument(t *testing.T, content string) *Document {
+	doc := &Document{}
+	if err := json.Unmarshal([]byte(content), doc); err != nil {
+		t.Fatalf("cannot load document: %v", err)
+	}
+	return doc
+}
+
+func findProperty(props []*api.Type, name string) *api.Type {
+	for _, p := range props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestBuildProduct(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		document    string
+		name        string
+		version     string
+		baseUrl     string
+		scopes      []string
+	}{
+		{"version in the method paths", lroDocument, "Widget", "ga", "https://widget.googleapis.com/v1/", []string{"https://www.googleapis.com/auth/cloud-platform"}},
+		{"version in the service path", computeDocument, "Gadget", "beta", "https://gadget.googleapis.com/gadget/beta/", []string{"https://www.googleapis.com/auth/gadget"}},
+	}
+	for _, tc := range cases {
+		p := BuildProduct(loadTestDocument(t, tc.document))
+		if p.Name != tc.name {
+			t.Errorf("%s: expected name %q, got %q", tc.description, tc.name, p.Name)
+		}
+		if p.Versions[0].Name != tc.version || p.Versions[0].BaseUrl != tc.baseUrl {
+			t.Errorf("%s: expected version %s at %q, got %s at %q", tc.description, tc.version, tc.baseUrl, p.Versions[0].Name, p.Versions[0].BaseUrl)
+		}
+		if !reflect.DeepEqual(p.Scopes, tc.scopes) {
+			t.Errorf("%s: expected scopes %v, got %v", tc.description, tc.scopes, p.Scopes)
+		}
+	}
+}
+
+func TestBuildResourcesLongRunningOperations(t *testing.T) {
+	t.Parallel()
+
+	resources := BuildResources(loadTestDocument(t, lroDocument))
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+	resource := resources[0]
+
+	if resource.Name != "Widget" {
+		t.Errorf("expected resource Widget, got %q", resource.Name)
+	}
+	if got, want := resource.BaseUrl, "projects/{{project}}/locations/{{location}}/widgets"; got != want {
+		t.Errorf("expected base_url %q, got %q", want, got)
+	}
+	if got, want := resource.SelfLink, "projects/{{project}}/locations/{{location}}/widgets/{{name}}"; got != want {
+		t.Errorf("expected self_link %q, got %q", want, got)
+	}
+	if got, want := resource.CreateUrl, "projects/{{project}}/locations/{{location}}/widgets?widgetId={{name}}"; got != want {
+		t.Errorf("expected create_url %q, got %q", want, got)
+	}
+	if resource.UpdateVerb != "PATCH" || !resource.UpdateMask {
+		t.Errorf("expected a PATCH update with an update mask, got %q and %v", resource.UpdateVerb, resource.UpdateMask)
+	}
+	if !resource.ExcludeDelete {
+		t.Errorf("expected delete to be excluded when there is no delete method")
+	}
+	if resource.Async == nil || !reflect.DeepEqual(resource.Async.Actions, []string{"create", "update"}) || !resource.Async.Result.ResourceInsideResponse {
+		t.Errorf("expected an async block for create and update with the resource in the response, got %+v", resource.Async)
+	}
+
+	if p := findProperty(resource.Parameters, "location"); p == nil || !p.UrlParamOnly || !p.Immutable {
+		t.Errorf("expected an immutable location url parameter, got %+v", p)
+	}
+	if p := findProperty(resource.Parameters, "name"); p == nil || p.Description != "Id of the widget." {
+		t.Errorf("expected a name url parameter described by widgetId, got %+v", p)
+	}
+	if p := findProperty(resource.Properties, "name"); p != nil {
+		t.Errorf("expected name to be a url parameter only")
+	}
+
+	cases := []struct {
+		description string
+		name        string
+		fieldType   string
+		output      bool
+		required    bool
+		immutable   bool
+	}{
+		{"readOnly fields are output", "state", "Enum", true, false, false},
+		{"Required. marks required fields", "tier", "Enum", false, true, false},
+		{"Immutable. marks immutable fields", "zone", "String", false, false, true},
+		{"labels use the standard labels type", "labels", "KeyValueLabels", false, false, false},
+		{"references become nested objects", "tree", "NestedObject", false, false, false},
+	}
+	for _, tc := range cases {
+		p := findProperty(resource.Properties, tc.name)
+		if p == nil {
+			t.Errorf("%s: property %s not found", tc.description, tc.name)
+			continue
+		}
+		if p.Type != tc.fieldType || p.Output != tc.output || p.Required != tc.required || p.Immutable != tc.immutable {
+			t.Errorf("%s: expected %s with output %v, required %v and immutable %v, got %s with %v, %v and %v",
+				tc.description, tc.fieldType, tc.output, tc.required, tc.immutable, p.Type, p.Output, p.Required, p.Immutable)
+		}
+	}
+
+	if got, want := findProperty(resource.Properties, "tier").EnumValues, []string{"BASIC", "PREMIUM"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected enum values %v without the unspecified value, got %v", want, got)
+	}
+	children := findProperty(findProperty(resource.Properties, "tree").Properties, "children")
+	if children == nil || children.ItemType == nil || children.ItemType.Type != "String" || children.ItemType.CustomExpand == "" {
+		t.Errorf("expected the recursive item type to be a JSON string, got %+v", children)
+	}
+}
+
+func TestBuildResourcesCompute(t *testing.T) {
+	t.Parallel()
+
+	resources := BuildResources(loadTestDocument(t, computeDocument))
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+	resource := resources[0]
+
+	if got, want := resource.SelfLink, "projects/{{project}}/regions/{{region}}/gadgets/{{name}}"; got != want {
+		t.Errorf("expected self_link %q, got %q", want, got)
+	}
+	if resource.CreateUrl != "" {
+		t.Errorf("expected resources to be created at base_url, got create_url %q", resource.CreateUrl)
+	}
+	if !resource.Immutable || resource.ExcludeDelete {
+		t.Errorf("expected an immutable resource that can be deleted")
+	}
+	if resource.Async == nil || !reflect.DeepEqual(resource.Async.Actions, []string{"create", "delete"}) || resource.Async.Result.ResourceInsideResponse {
+		t.Errorf("expected an async block for create and delete pointing to the resource, got %+v", resource.Async)
+	}
+
+	if p := findProperty(resource.Parameters, "region"); p == nil || p.Description != "Name of the region." {
+		t.Errorf("expected a region url parameter, got %+v", p)
+	}
+	if p := findProperty(resource.Parameters, "name"); p != nil {
+		t.Errorf("expected name to be sent in the request body")
+	}
+	if p := findProperty(resource.Properties, "name"); p == nil || !p.Required || !p.Immutable {
+		t.Errorf("expected a required, immutable name property, got %+v", p)
+	}
+	if p := findProperty(resource.Properties, "size"); p == nil || !p.Required || p.Type != "Integer" {
+		t.Errorf("expected size to be required by the insert annotation, got %+v", p)
+	}
+	if p := findProperty(resource.Properties, "selfLink"); p == nil || !p.Output || p.Description != "Server-defined URL for the resource." {
+		t.Errorf("expected selfLink to be output with [Output Only] removed from its description, got %+v", p)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api_description"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/discovery_generate"
+)
+
+func loadDiscovery(filePath string) ([]*Resource, error) {
+	doc, err := discovery_generate.ReadDocument(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*Resource
+	var walk func(collections map[string]*discovery_generate.Resource)
+	walk = func(collections map[string]*discovery_generate.Resource) {
+		for _, key := range slices.Sorted(maps.Keys(collections)) {
+			collection := collections[key]
+			walk(collection.Resources)
+
+			create := discovery_generate.CreateMethod(collection)
+			if create == nil || create.Request == nil {
+				continue
+			}
+			resource := &Resource{
+				Name:   create.Request.Ref,
+				Source: filePath,
+				Url:    normalizeUrl(discovery_generate.ProductBaseUrl(doc) + discovery_generate.CollectionUrl(create)),
+			}
+			request := doc.Resolve(create.Request)
+			seen := map[string]bool{create.Request.Ref: true}
+			for _, k := range slices.Sorted(maps.Keys(request.Properties)) {
+				resource.Fields = append(resource.Fields, discoveryField(doc, k, request.Properties[k], seen))
+			}
+			resources = append(resources, resource)
+		}
+	}
+	walk(doc.Resources)
+	return resources, nil
+}
+
+// seen holds the schemas being read by the callers. Recursive schemas end in
+// a field of type any.
+func discoveryField(doc *discovery_generate.Document, name string, s *discovery_generate.Schema, seen map[string]bool) *Field {
+	field := &Field{Name: name, Type: "any"}
+	if s.Ref != "" {
+		if seen[s.Ref] {
+			return field
+		}
+		seen[s.Ref] = true
+		defer delete(seen, s.Ref)
+	}
+
+	resolved := doc.Resolve(s)
+	field.Type = resolved.Type
+	field.Format = resolved.Format
+	field.Enum = api_description.EnumValues(resolved.Enum)
+	field.Output = s.ReadOnly || resolved.ReadOnly || outputOnly(s.Description) || outputOnly(resolved.Description)
+
+	switch resolved.Type {
+	case "array":
+		if resolved.Items != nil {
+			field.Item = discoveryField(doc, name, resolved.Items, seen)
+		}
+	case "object":
+		if resolved.AdditionalProperties != nil {
+			field.Map = true
+			break
+		}
+		if len(resolved.Properties) == 0 {
+			field.Type = "any"
+			break
+		}
+		for _, k := range slices.Sorted(maps.Keys(resolved.Properties)) {
+			field.Fields = append(field.Fields, discoveryField(doc, k, resolved.Properties[k], seen))
+		}
+	}
+	return field
+}
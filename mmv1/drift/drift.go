@@ -0,0 +1,304 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drift compares resource YAML with the OpenAPI or discovery
+// description of the API it targets, to find where a resource has fallen
+// behind its API.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api_description"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Names of the kinds of drift, reported as the rule of each diagnostic.
+const (
+	MissingField   = "missing-field"
+	TypeMismatch   = "type-mismatch"
+	EnumMismatch   = "enum-mismatch"
+	OutputMismatch = "output-mismatch"
+)
+
+// A resource as described by an API description file.
+type Resource struct {
+	Name string
+
+	// File the resource was read from
+	Source string
+
+	// Url of the collection holding the resource, without the scheme, the
+	// API version or the names of parameters, eg:
+	// pubsub.googleapis.com/projects/{}/topics
+	Url string
+
+	Fields []*Field
+}
+
+// A field of an API resource.
+type Field struct {
+	Name string
+
+	// JSON type: string, integer, number, boolean, array, object or any
+	Type string
+
+	// Format of strings and numbers, eg: int64 or google-datetime
+	Format string
+
+	Enum []string
+
+	Output bool
+
+	// Properties of an object
+	Fields []*Field
+
+	// Set for objects used as maps, eg: labels
+	Map bool
+
+	// Items of an array
+	Item *Field
+}
+
+// Reads the resources of an OpenAPI or discovery file, or of every such file
+// in a directory.
+func Load(filePath string) ([]*Resource, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return loadFile(filePath)
+	}
+
+	var resources []*Resource
+	entries, err := os.ReadDir(filePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains([]string{".json", ".yaml", ".yml"}, filepath.Ext(entry.Name())) {
+			continue
+		}
+		fileResources, err := loadFile(filepath.Join(filePath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, fileResources...)
+	}
+	return resources, nil
+}
+
+func loadFile(filePath string) ([]*Resource, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discovery documents are JSON with a discoveryVersion, anything else is
+	// assumed to be OpenAPI.
+	var header struct {
+		DiscoveryVersion string `json:"discoveryVersion"`
+	}
+	if json.Unmarshal(content, &header) == nil && header.DiscoveryVersion != "" {
+		return loadDiscovery(filePath)
+	}
+	return loadOpenApi(filePath, content)
+}
+
+var urlParamRegex = regexp.MustCompile(`\{\{?[^{}]*\}?\}`)
+
+var urlVersionRegex = regexp.MustCompile(`^(v\d[^/]*|beta|alpha)$`)
+
+// Normalizes a url so that urls of the same collection compare equal across
+// API versions and parameter naming conventions.
+func normalizeUrl(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	}
+	url, _, _ = strings.Cut(url, "?")
+	url = urlParamRegex.ReplaceAllString(url, "{}")
+	// Regional endpoints, eg: {{region}}-aiplatform.googleapis.com
+	url = strings.TrimPrefix(url, "{}-")
+
+	var segments []string
+	for _, segment := range strings.Split(url, "/") {
+		if segment == "" || urlVersionRegex.MatchString(segment) {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// Returns the API resource with the same collection url as r, or nil if
+// there is none.
+func Match(r *api.Resource, baseUrl string, resources []*Resource) *Resource {
+	url := normalizeUrl(baseUrl + r.BaseUrl)
+	for _, resource := range resources {
+		if resource.Url == url {
+			return resource
+		}
+	}
+	return nil
+}
+
+// Records drift of the property at lineage. rule is one of the drift kinds.
+type Reporter func(rule, lineage, format string, a ...any)
+
+// Reports fields of resource missing from the properties of r, and fields
+// whose type, enum values or output status disagree.
+func Compare(r *api.Resource, resource *Resource, report Reporter) {
+	compareFields(append(slices.Clone(r.Parameters), r.Properties...), resource.Fields, "", report)
+}
+
+func compareFields(props []*api.Type, fields []*Field, parentLineage string, report Reporter) {
+	byApiName := make(map[string]*api.Type)
+	for _, p := range props {
+		// Fields added by MMv1 rather than the API
+		if p.ClientSide || p.IsA("KeyValueTerraformLabels") || p.IsA("KeyValueEffectiveLabels") {
+			continue
+		}
+		byApiName[apiName(p)] = p
+	}
+
+	for _, f := range fields {
+		p, ok := byApiName[f.Name]
+		if !ok {
+			lineage := google.Underscore(f.Name)
+			if parentLineage != "" {
+				lineage = fmt.Sprintf("%s.%s", parentLineage, lineage)
+			}
+			report(MissingField, lineage, "%s is in the API but missing from the resource", f.Name)
+			continue
+		}
+		if p.UrlParamOnly {
+			continue
+		}
+		compareField(p, f, p.Lineage(), true, report)
+	}
+}
+
+// Items of arrays don't have an output status of their own, so
+// compareOutput is only set for fields.
+func compareField(p *api.Type, f *Field, lineage string, compareOutput bool, report Reporter) {
+	if compatible := compatibleTypes(f); compatible != nil && !slices.Contains(compatible, p.Type) {
+		report(TypeMismatch, lineage, "type is %s but the API uses %s", p.Type, describe(f))
+		return
+	}
+
+	if compareOutput && p.Output != f.Output {
+		if f.Output {
+			report(OutputMismatch, lineage, "the API marks the field output only")
+		} else {
+			report(OutputMismatch, lineage, "marked output but the API accepts the field")
+		}
+	}
+
+	switch p.Type {
+	case "Enum":
+		compareEnum(p, f, lineage, report)
+	case "NestedObject":
+		compareFields(p.Properties, f.Fields, lineage, report)
+	case "Array":
+		if p.ItemType != nil && f.Item != nil {
+			compareField(p.ItemType, f.Item, lineage, false, report)
+		}
+	}
+}
+
+func compareEnum(p *api.Type, f *Field, lineage string, report Reporter) {
+	var added, removed []string
+	for _, v := range f.Enum {
+		if !slices.Contains(p.EnumValues, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range api_description.EnumValues(p.EnumValues) {
+		if !slices.Contains(f.Enum, v) {
+			removed = append(removed, v)
+		}
+	}
+	if len(added) > 0 {
+		report(EnumMismatch, lineage, "enum values in the API but not the resource: %s", strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		report(EnumMismatch, lineage, "enum values in the resource but not the API: %s", strings.Join(removed, ", "))
+	}
+}
+
+func apiName(p *api.Type) string {
+	if p.ApiName != "" {
+		return p.ApiName
+	}
+	return p.Name
+}
+
+// Returns the MMv1 types that can represent f, or nil if any type can.
+func compatibleTypes(f *Field) []string {
+	switch f.Type {
+	case "string":
+		switch {
+		case len(f.Enum) > 0:
+			return []string{"Enum", "String"}
+		case f.Format == "int64" || f.Format == "uint64":
+			return []string{"String"}
+		case f.Format == "google-datetime" || f.Format == "date-time":
+			return []string{"String", "Time"}
+		default:
+			return []string{"String", "Fingerprint", "ResourceRef"}
+		}
+	case "integer":
+		return []string{"Integer"}
+	case "number":
+		return []string{"Double"}
+	case "boolean":
+		return []string{"Boolean"}
+	case "array":
+		return []string{"Array"}
+	case "object":
+		if f.Map {
+			return []string{"KeyValuePairs", "KeyValueLabels", "KeyValueAnnotations", "Map"}
+		}
+		return []string{"NestedObject"}
+	default:
+		// Free-form values are usually written as JSON strings
+		return nil
+	}
+}
+
+func describe(f *Field) string {
+	switch {
+	case len(f.Enum) > 0:
+		return "an enum"
+	case f.Type == "string" && (f.Format == "int64" || f.Format == "uint64"):
+		return fmt.Sprintf("a string-encoded %s", f.Format)
+	case f.Map:
+		return "a map"
+	default:
+		return f.Type
+	}
+}
+
+// Returns true if a description marks its field as output only.
+func outputOnly(description string) bool {
+	description = strings.TrimSpace(description)
+	return strings.HasPrefix(description, "[Output Only]") || strings.HasPrefix(description, "Output only.")
+}
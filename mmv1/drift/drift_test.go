@@ -0,0 +1,134 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestNormalizeUrl(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		url         string
+		expected    string
+	}{
+		{"mmv1 parameters", "https://pubsub.googleapis.com/v1/projects/{{project}}/topics", "pubsub.googleapis.com/projects/{}/topics"},
+		{"discovery parameters", "https://pubsub.googleapis.com/v1beta2/projects/{projectsId}/topics", "pubsub.googleapis.com/projects/{}/topics"},
+		{"version in the service path", "https://compute.googleapis.com/compute/beta/projects/{project}/regions/{region}/addresses", "compute.googleapis.com/compute/projects/{}/regions/{}/addresses"},
+		{"regional endpoint", "https://{{region}}-aiplatform.googleapis.com/v1/projects/{{project}}/locations/{{region}}/datasets", "aiplatform.googleapis.com/projects/{}/locations/{}/datasets"},
+		{"query parameters", "https://file.googleapis.com/v1/projects/{{project}}/locations/{{location}}/instances?instanceId={{name}}", "file.googleapis.com/projects/{}/locations/{}/instances"},
+	}
+	for _, tc := range cases {
+		if got := normalizeUrl(tc.url); got != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.description, tc.expected, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	resource := &api.Resource{
+		Parameters: []*api.Type{
+			{Name: "name", Type: "String", UrlParamOnly: true},
+		},
+		Properties: []*api.Type{
+			{Name: "size", Type: "Integer"},
+			{Name: "tier", Type: "Enum", EnumValues: []string{"TIER_UNSPECIFIED", "BASIC", "LEGACY"}},
+			{Name: "createTime", Type: "String"},
+			{Name: "displayName", Type: "String", Output: true},
+			{Name: "zoneName", ApiName: "zone", Type: "String"},
+			{Name: "settings", Type: "NestedObject", Properties: []*api.Type{
+				{Name: "color", Type: "String"},
+			}},
+			{Name: "labels", Type: "KeyValueLabels"},
+		},
+	}
+	apiResource := &Resource{
+		Fields: []*Field{
+			{Name: "name", Type: "string", Output: true},
+			{Name: "size", Type: "string", Format: "int64"},
+			{Name: "tier", Type: "string", Enum: []string{"BASIC", "PREMIUM"}},
+			{Name: "createTime", Type: "string", Format: "google-datetime", Output: true},
+			{Name: "displayName", Type: "string"},
+			{Name: "zone", Type: "string"},
+			{Name: "settings", Type: "object", Fields: []*Field{
+				{Name: "color", Type: "string"},
+				{Name: "sizeGb", Type: "integer"},
+			}},
+			{Name: "labels", Type: "object", Map: true},
+			{Name: "etag", Type: "string"},
+		},
+	}
+
+	var got []string
+	Compare(resource, apiResource, func(rule, lineage, format string, a ...any) {
+		got = append(got, fmt.Sprintf("%s %s: %s", rule, lineage, fmt.Sprintf(format, a...)))
+	})
+
+	expected := []string{
+		"type-mismatch size: type is Integer but the API uses a string-encoded int64",
+		"enum-mismatch tier: enum values in the API but not the resource: PREMIUM",
+		"enum-mismatch tier: enum values in the resource but not the API: LEGACY",
+		"output-mismatch create_time: the API marks the field output only",
+		"output-mismatch display_name: marked output but the API accepts the field",
+		"missing-field settings.size_gb: sizeGb is in the API but missing from the resource",
+		"missing-field etag: etag is in the API but missing from the resource",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected drift:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestLoadDiscovery(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), "pubsub.discovery.json")
+	content := `{
+  "discoveryVersion": "v1",
+  "name": "pubsub",
+  "version": "v1",
+  "rootUrl": "https://pubsub.googleapis.com/",
+  "servicePath": "",
+  "schemas": {
+    "Topic": {"id": "Topic", "type": "object", "properties": {
+      "state": {"type": "string", "description": "Output only. State of the topic.", "enum": ["STATE_UNSPECIFIED", "ACTIVE"]},
+      "parent": {"$ref": "Topic"}
+    }}
+  },
+  "resources": {"projects": {"resources": {"topics": {"methods": {
+    "create": {
+      "path": "v1/{+name}",
+      "flatPath": "v1/projects/{projectsId}/topics/{topicsId}",
+      "httpMethod": "PUT",
+      "request": {"$ref": "Topic"}
+    }
+  }}}}}
+}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := Load(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*Resource{{
+		Name:   "Topic",
+		Source: filePath,
+		Url:    "pubsub.googleapis.com/projects/{}/topics",
+		Fields: []*Field{
+			{Name: "parent", Type: "any"},
+			{Name: "state", Type: "string", Enum: []string{"ACTIVE"}, Output: true},
+		},
+	}}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("expected %+v, got %+v", expected[0], resources[0])
+	}
+}
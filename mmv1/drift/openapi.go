@@ -0,0 +1,114 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api_description"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
+)
+
+func loadOpenApi(filePath string, content []byte) ([]*Resource, error) {
+	loader := &openapi3.Loader{Context: context.Background(), IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromData(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s as OpenAPI: %w", filePath, err)
+	}
+	if len(doc.Servers) == 0 {
+		return nil, fmt.Errorf("%s does not list any servers", filePath)
+	}
+	server := strings.TrimSuffix(doc.Servers[0].URL, "/")
+
+	resourcePaths := openapi_generate.FindResources(doc)
+	slices.SortFunc(resourcePaths, func(a, b []string) int {
+		return strings.Compare(a[0], b[0])
+	})
+
+	var resources []*Resource
+	for _, pathArray := range resourcePaths {
+		resource := &Resource{
+			Name:   pathArray[1],
+			Source: filePath,
+			Url:    normalizeUrl(server + pathArray[0]),
+		}
+
+		create := doc.Paths.Find(pathArray[0]).Post
+		if create.RequestBody != nil && create.RequestBody.Value != nil {
+			if mediaType := create.RequestBody.Value.Content.Get("application/json"); mediaType != nil && mediaType.Schema != nil {
+				body := openapi_generate.MergedSchema(mediaType.Schema)
+				seen := map[*openapi3.Schema]bool{mediaType.Schema.Value: true}
+				for _, k := range slices.Sorted(maps.Keys(body.Properties)) {
+					resource.Fields = append(resource.Fields, openApiField(k, body.Properties[k], seen))
+				}
+			}
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// seen holds the schemas being read by the callers. Recursive schemas end in
+// a field of type any.
+func openApiField(name string, ref *openapi3.SchemaRef, seen map[*openapi3.Schema]bool) *Field {
+	field := &Field{Name: name, Type: "any"}
+	if seen[ref.Value] {
+		return field
+	}
+	seen[ref.Value] = true
+	defer delete(seen, ref.Value)
+
+	s := openapi_generate.MergedSchema(ref)
+	field.Type = openapi_generate.SchemaType(s)
+	if s.Type == nil && field.Type == openapi3.TypeString {
+		// Schemas without a type accept any value, eg: google.protobuf.Value
+		field.Type = "any"
+	}
+	field.Format = s.Format
+	for _, v := range s.Enum {
+		field.Enum = append(field.Enum, fmt.Sprintf("%v", v))
+	}
+	field.Enum = api_description.EnumValues(field.Enum)
+
+	// x-google-identifier fields are described by AIP 203 and are represented
+	// as output only in Terraform.
+	_, identifier := s.Extensions["x-google-identifier"]
+	field.Output = s.ReadOnly || identifier || outputOnly(s.Description)
+
+	switch field.Type {
+	case openapi3.TypeArray:
+		if s.Items != nil {
+			field.Item = openApiField(name, s.Items, seen)
+		}
+	case openapi3.TypeObject:
+		if s.AdditionalProperties.Schema != nil || (s.AdditionalProperties.Has != nil && *s.AdditionalProperties.Has) {
+			field.Map = true
+			break
+		}
+		if len(s.Properties) == 0 {
+			field.Type = "any"
+			break
+		}
+		for _, k := range slices.Sorted(maps.Keys(s.Properties)) {
+			field.Fields = append(field.Fields, openApiField(k, s.Properties[k], seen))
+		}
+	}
+	return field
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// A single problem found while parsing or validating a YAML file.
+type Diagnostic struct {
+	File string `json:"file,omitempty"`
+
+	Line int `json:"line,omitempty"`
+
+	Column int `json:"column,omitempty"`
+
+	// Dot notation path of the property the problem belongs to, in the same
+	// format as Type.Lineage(). eg: parent.meta.label.foo
+	// Empty for problems that apply to the whole file.
+	Lineage string `json:"lineage,omitempty"`
+
+	Message string `json:"message"`
+
+	// Set for findings that do not stop generation, such as lint warnings.
+	// Empty means the diagnostic is an error.
+	Severity string `json:"severity,omitempty"`
+
+	// Name of the lint rule that reported the diagnostic, if any.
+	Rule string `json:"rule,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	sb.WriteString(d.File)
+	if d.Line > 0 {
+		sb.WriteString(fmt.Sprintf(":%d", d.Line))
+		if d.Column > 0 {
+			sb.WriteString(fmt.Sprintf(":%d", d.Column))
+		}
+	}
+	if d.Severity != "" {
+		sb.WriteString(fmt.Sprintf(": %s", d.Severity))
+	}
+	if d.Rule != "" {
+		sb.WriteString(fmt.Sprintf(" [%s]", d.Rule))
+	}
+	if d.Lineage != "" {
+		sb.WriteString(fmt.Sprintf(": %s", d.Lineage))
+	}
+	sb.WriteString(fmt.Sprintf(": %s", d.Message))
+	return sb.String()
+}
+
+func (d Diagnostic) IsError() bool {
+	return d.Severity == "" || d.Severity == "error"
+}
+
+type position struct {
+	line   int
+	column int
+}
+
+// Collects diagnostics across every product and resource loaded in a run so
+// that they can be reported together instead of aborting on the first one.
+// It is safe for concurrent use.
+//
+// A nil *Diagnostics fails fast: the first reported problem is fatal.
+type Diagnostics struct {
+	mu          sync.Mutex
+	diagnostics []Diagnostic
+
+	// Positions of properties within each parsed file, keyed by file path
+	// and then by property lineage.
+	positions map[string]map[string]position
+}
+
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		positions: make(map[string]map[string]position),
+	}
+}
+
+// Records a problem found in the file at yamlPath. The line and column are
+// looked up from the parsed YAML using the lineage, falling back to the
+// closest parent property when the exact property cannot be located.
+func (d *Diagnostics) Errorf(yamlPath, lineage, format string, a ...any) {
+	d.Report(Diagnostic{
+		File:    yamlPath,
+		Lineage: lineage,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// Records a diagnostic, filling in its line and column from the lineage the
+// same way as Errorf when they are not already set.
+func (d *Diagnostics) Report(diagnostic Diagnostic) {
+	if d != nil && diagnostic.Line == 0 {
+		d.mu.Lock()
+		if pos, ok := d.lookupPosition(diagnostic.File, diagnostic.Lineage); ok {
+			diagnostic.Line = pos.line
+			diagnostic.Column = pos.column
+		}
+		d.mu.Unlock()
+	}
+	d.add(diagnostic)
+}
+
+func (d *Diagnostics) add(diagnostic Diagnostic) {
+	if d == nil {
+		log.Fatal(diagnostic.String())
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.diagnostics = append(d.diagnostics, diagnostic)
+}
+
+// Adds every diagnostic collected by other to d.
+func (d *Diagnostics) Append(other *Diagnostics) {
+	for _, diagnostic := range other.All() {
+		d.add(diagnostic)
+	}
+}
+
+// Whether any diagnostic is an error, as opposed to a warning or other
+// finding that should not fail the run.
+func (d *Diagnostics) HasErrors() bool {
+	if d == nil {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, diagnostic := range d.diagnostics {
+		if diagnostic.IsError() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns all diagnostics, ordered by file and then by position.
+func (d *Diagnostics) All() []Diagnostic {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	all := make([]Diagnostic, len(d.diagnostics))
+	copy(all, d.diagnostics)
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].File != all[j].File {
+			return all[i].File < all[j].File
+		}
+		if all[i].Line != all[j].Line {
+			return all[i].Line < all[j].Line
+		}
+		return all[i].Column < all[j].Column
+	})
+	return all
+}
+
+// Writes one diagnostic per line in the file:line:column format understood
+// by most editors.
+func (d *Diagnostics) WriteText(w io.Writer) error {
+	for _, diagnostic := range d.All() {
+		if _, err := fmt.Fprintln(w, diagnostic.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Diagnostics) WriteJSON(w io.Writer) error {
+	all := d.All()
+	if all == nil {
+		all = []Diagnostic{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(all)
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Splits an error returned by yaml.v2 into one diagnostic per problem,
+// keeping the line number yaml.v2 reports for each.
+func (d *Diagnostics) addYamlError(yamlPath string, err error) {
+	messages := []string{err.Error()}
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	}
+
+	for _, message := range messages {
+		match := yamlErrorLineRegexp.FindStringSubmatch(strings.TrimSpace(message))
+		if match == nil {
+			d.Errorf(yamlPath, "", "%s", message)
+			continue
+		}
+		line, _ := strconv.Atoi(match[1])
+		d.add(Diagnostic{
+			File:    yamlPath,
+			Line:    line,
+			Message: match[2],
+		})
+	}
+}
+
+// Must be called with d.mu held.
+func (d *Diagnostics) lookupPosition(yamlPath, lineage string) (position, bool) {
+	positions, ok := d.positions[yamlPath]
+	if !ok {
+		return position{}, false
+	}
+	for lineage != "" {
+		if pos, ok := positions[lineage]; ok {
+			return pos, true
+		}
+		i := strings.LastIndex(lineage, ".")
+		if i < 0 {
+			break
+		}
+		lineage = lineage[:i]
+	}
+	return position{}, false
+}
+
+// Records where each property of a resource file is declared so that later
+// validation diagnostics can point at it.
+func (d *Diagnostics) indexPositions(yamlPath string, content []byte) {
+	if d == nil {
+		return
+	}
+
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &root); err != nil || len(root.Content) == 0 {
+		return
+	}
+
+	positions := make(map[string]position)
+	doc := root.Content[0]
+	for _, key := range []string{"virtual_fields", "parameters", "properties"} {
+		if props := mappingValue(doc, key); props != nil {
+			indexProperties(props, "", positions)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.positions[yamlPath] = positions
+}
+
+// Walks a list of properties, mirroring how Type.Lineage() names nested
+// properties, item types and value types.
+func indexProperties(props *yamlv3.Node, parentLineage string, positions map[string]position) {
+	if props.Kind != yamlv3.SequenceNode {
+		return
+	}
+	for _, prop := range props.Content {
+		nameNode := mappingValue(prop, "name")
+		if nameNode == nil {
+			continue
+		}
+		lineage := Underscore(nameNode.Value)
+		if parentLineage != "" {
+			lineage = fmt.Sprintf("%s.%s", parentLineage, lineage)
+		}
+		positions[lineage] = position{line: prop.Line, column: prop.Column}
+		indexNestedType(prop, nameNode.Value, lineage, positions)
+	}
+}
+
+func indexNestedType(prop *yamlv3.Node, name, lineage string, positions map[string]position) {
+	if nested := mappingValue(prop, "properties"); nested != nil {
+		indexProperties(nested, lineage, positions)
+	}
+
+	for _, key := range []string{"item_type", "value_type"} {
+		childType := mappingValue(prop, key)
+		if childType == nil || childType.Kind != yamlv3.MappingNode {
+			continue
+		}
+		// Item types always take the name of the field containing them, while
+		// value types only do so when they are not named explicitly.
+		childName := name
+		if childNameNode := mappingValue(childType, "name"); key == "value_type" && childNameNode != nil {
+			childName = childNameNode.Value
+		}
+		childLineage := fmt.Sprintf("%s.%s", lineage, Underscore(childName))
+		positions[childLineage] = position{line: childType.Line, column: childType.Column}
+		indexNestedType(childType, childName, childLineage, positions)
+	}
+}
+
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestYamlValidatorParseCollectsErrors(t *testing.T) {
+	t.Parallel()
+
+	type obj struct {
+		Name  string
+		Count int
+	}
+
+	content := []byte(`name: foo
+count: bar
+unknown: baz
+`)
+
+	diags := NewDiagnostics()
+	v := YamlValidator{Diagnostics: diags}
+	if err := v.Parse(content, &obj{}, "products/foo/Bar.yaml"); err == nil {
+		t.Fatalf("expected an error parsing invalid YAML")
+	}
+
+	got := diags.All()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(got), got)
+	}
+	for i, wantLine := range []int{2, 3} {
+		if got[i].File != "products/foo/Bar.yaml" || got[i].Line != wantLine {
+			t.Errorf("expected diagnostic %d at products/foo/Bar.yaml:%d, got %s", i, wantLine, got[i])
+		}
+	}
+}
+
+func TestDiagnosticsErrorfPosition(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`name: Bar
+properties:
+  - name: labels
+    type: KeyValueLabels
+  - name: config
+    type: NestedObject
+    properties:
+      - name: diskSize
+        type: Integer
+  - name: rules
+    type: Array
+    item_type:
+      type: NestedObject
+      properties:
+        - name: action
+          type: String
+`)
+
+	diags := NewDiagnostics()
+	v := YamlValidator{Diagnostics: diags}
+	var parsed map[string]interface{}
+	if err := v.Parse(content, &parsed, "Bar.yaml"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		description string
+		lineage     string
+		expected    Diagnostic
+	}{
+		{
+			description: "top-level property",
+			lineage:     "labels",
+			expected:    Diagnostic{File: "Bar.yaml", Line: 3, Column: 5, Lineage: "labels", Message: "problem"},
+		},
+		{
+			description: "nested property",
+			lineage:     "config.disk_size",
+			expected:    Diagnostic{File: "Bar.yaml", Line: 8, Column: 9, Lineage: "config.disk_size", Message: "problem"},
+		},
+		{
+			description: "array item property",
+			lineage:     "rules.rules.action",
+			expected:    Diagnostic{File: "Bar.yaml", Line: 15, Column: 11, Lineage: "rules.rules.action", Message: "problem"},
+		},
+		{
+			description: "unknown property falls back to parent",
+			lineage:     "config.missing",
+			expected:    Diagnostic{File: "Bar.yaml", Line: 5, Column: 5, Lineage: "config.missing", Message: "problem"},
+		},
+		{
+			description: "file-level problem",
+			lineage:     "",
+			expected:    Diagnostic{File: "Bar.yaml", Message: "problem"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDiagnostics()
+			d.positions = diags.positions
+			d.Errorf("Bar.yaml", tc.lineage, "problem")
+
+			if got, want := d.All()[0], tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
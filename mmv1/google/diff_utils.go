@@ -0,0 +1,228 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// Files needing more edits than this are shown as a single hunk replacing
+// every line, to bound the memory used by the diff.
+const maxDiffEdits = 2000
+
+type diffLine struct {
+	// ' ' for unchanged lines, '-' for removed lines and '+' for added lines
+	op   byte
+	text string
+}
+
+// Returns a unified diff turning oldContent into newContent, or "" if they
+// are equal. oldName and newName are used in the file header; pass
+// /dev/null for a file that is added or deleted.
+func UnifiedDiff(oldName, newName, oldContent, newContent string) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	lines := diffLines(splitLines(oldContent), splitLines(newContent))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Line numbers before each entry of lines, in the old and new file
+	oldLine, newLine := make([]int, len(lines)+1), make([]int, len(lines)+1)
+	oldLine[0], newLine[0] = 1, 1
+	for i, l := range lines {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if l.op != '+' {
+			oldLine[i+1]++
+		}
+		if l.op != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(lines); {
+		// Find the next change and extend the hunk until the unchanged
+		// gap to the following change is too large to share context.
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for i := first + 1; i < len(lines); i++ {
+			if lines[i].op == ' ' {
+				continue
+			}
+			if i-last-1 > 2*diffContextLines {
+				break
+			}
+			last = i
+		}
+
+		from := max(first-diffContextLines, start)
+		to := min(last+diffContextLines+1, len(lines))
+
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(oldLine[from], oldLine[to]-oldLine[from]), hunkRange(newLine[from], newLine[to]-newLine[from]))
+		for _, l := range lines[from:to] {
+			sb.WriteByte(l.op)
+			sb.WriteString(l.text)
+			if !strings.HasSuffix(l.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		start = to
+	}
+
+	return sb.String()
+}
+
+// Formats a hunk range the way diff -u does: an empty range points at the
+// line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Splits s into lines, keeping the line terminators so that a missing
+// newline at the end of the file shows up as a change.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Returns the shortest edit script turning a into b, using Myers' algorithm.
+func diffLines(a, b []string) []diffLine {
+	// Unchanged lines at either end don't need to go through the algorithm
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, l := range a[:prefix] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	lines = append(lines, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, l := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	return lines
+}
+
+func myers(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	// trace[d] holds v[-d..d] as it was before step d, for backtracking
+	var trace [][]int
+	found := -1
+	for d := 0; d <= n+m && d <= maxDiffEdits && found < 0; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = d
+				break
+			}
+		}
+	}
+
+	if found < 0 {
+		var lines []diffLine
+		for _, l := range a {
+			lines = append(lines, diffLine{'-', l})
+		}
+		for _, l := range b {
+			lines = append(lines, diffLine{'+', l})
+		}
+		return lines
+	}
+
+	// Walk back from the end, collecting lines in reverse
+	var reversed []diffLine
+	x, y := n, m
+	for d := found; d > 0; d-- {
+		previous := trace[d]
+		at := func(k int) int { return previous[k+d] }
+
+		k := x - y
+		var previousK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+		previousX := at(previousK)
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			reversed = append(reversed, diffLine{' ', a[x-1]})
+			x--
+			y--
+		}
+		if x == previousX {
+			reversed = append(reversed, diffLine{'+', b[y-1]})
+		} else {
+			reversed = append(reversed, diffLine{'-', a[x-1]})
+		}
+		x, y = previousX, previousY
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, diffLine{' ', a[x-1]})
+		x--
+		y--
+	}
+
+	lines := make([]diffLine, len(reversed))
+	for i, l := range reversed {
+		lines[len(reversed)-1-i] = l
+	}
+	return lines
+}
@@ -0,0 +1,143 @@
+package google
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		old         string
+		new         string
+		expected    string
+	}{
+		{
+			description: "equal files have no diff",
+			old:         "a\nb\n",
+			new:         "a\nb\n",
+			expected:    "",
+		},
+		{
+			description: "changed line with context",
+			old:         "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:         "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: `--- a/f
++++ b/f
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+`,
+		},
+		{
+			description: "distant changes get separate hunks",
+			old:         "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:         "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			expected: `--- a/f
++++ b/f
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -7,4 +7,4 @@
+ 7
+ 8
+ 9
+-10
++ten
+`,
+		},
+		{
+			description: "added file",
+			old:         "",
+			new:         "a\nb\n",
+			expected: `--- a/f
++++ b/f
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		},
+		{
+			description: "missing newline at end of file",
+			old:         "a\nb\n",
+			new:         "a\nb",
+			expected: `--- a/f
++++ b/f
+@@ -1,2 +1,2 @@
+ a
+-b
++b
+\ No newline at end of file
+`,
+		},
+		{
+			description: "insertion between unchanged lines",
+			old:         "a\nc\n",
+			new:         "a\nb\nc\n",
+			expected: `--- a/f
++++ b/f
+@@ -1,2 +1,3 @@
+ a
++b
+ c
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := UnifiedDiff("a/f", "b/f", tc.old, tc.new)
+			if got != tc.expected {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+// Applying the edit script to the old lines must give the new lines.
+func TestDiffLinesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	old := splitLines("a\nb\nc\na\nb\nb\na\n")
+	new := splitLines("c\nb\na\nb\na\nc\n")
+
+	var gotOld, gotNew []string
+	edits := 0
+	for _, l := range diffLines(old, new) {
+		if l.op != '+' {
+			gotOld = append(gotOld, l.text)
+		}
+		if l.op != '-' {
+			gotNew = append(gotNew, l.text)
+		}
+		if l.op != ' ' {
+			edits++
+		}
+	}
+
+	if strings.Join(gotOld, "") != strings.Join(old, "") {
+		t.Errorf("expected old lines %q, got %q", old, gotOld)
+	}
+	if strings.Join(gotNew, "") != strings.Join(new, "") {
+		t.Errorf("expected new lines %q, got %q", new, gotNew)
+	}
+	// The classic example from Myers' paper has a shortest edit script of 5
+	if edits != 5 {
+		t.Errorf("expected 5 edits, got %d", edits)
+	}
+}
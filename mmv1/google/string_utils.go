@@ -103,6 +103,54 @@ func Plural(source string) string {
 	return fmt.Sprintf("%ss", source)
 }
 
+// Returns the plural form of a noun. Unlike Plural, nouns ending in s, x, ch
+// or sh are handled, eg: address -> addresses, settings -> settings. Plural
+// is left as is since collection_url_key defaults to it.
+func PluralNoun(source string) string {
+	for _, suffix := range []string{"ss", "x", "ch", "sh"} {
+		if strings.HasSuffix(source, suffix) && !strings.HasSuffix(source, "ex") {
+			return fmt.Sprintf("%ses", source)
+		}
+	}
+	if strings.HasSuffix(source, "s") {
+		return source
+	}
+	return Plural(source)
+}
+
+// Returns the singular form of a plural noun, reversing PluralNoun, eg:
+// policies -> policy, addresses -> address, indices -> index. Words that
+// aren't plural are left as is.
+func Singular(source string) string {
+	// policies -> policy
+	if strings.HasSuffix(source, "ies") {
+		return strings.TrimSuffix(source, "ies") + "y"
+	}
+
+	// indices -> index
+	if strings.HasSuffix(source, "ices") {
+		return strings.TrimSuffix(source, "ices") + "ex"
+	}
+
+	// addresses -> address
+	// boxes -> box
+	// batches -> batch
+	// meshes -> mesh
+	for _, suffix := range []string{"sses", "xes", "ches", "shes"} {
+		if strings.HasSuffix(source, suffix) {
+			return strings.TrimSuffix(source, "es")
+		}
+	}
+
+	// address -> address
+	// status -> status
+	if strings.HasSuffix(source, "ss") || strings.HasSuffix(source, "us") {
+		return source
+	}
+
+	return strings.TrimSuffix(source, "s")
+}
+
 func Camelize(term string, firstLetter string) string {
 	if firstLetter != "upper" && firstLetter != "lower" {
 		log.Fatalf("Invalid option, use either upper or lower")
@@ -116,6 +116,142 @@ func TestStringPlural(t *testing.T) {
 	}
 }
 
+func TestStringPluralNoun(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		term        string
+		expected    string
+	}{
+		{
+			description: "PluralNoun normal string",
+			term:        "topic",
+			expected:    "topics",
+		},
+		{
+			description: "PluralNoun string ending with ss",
+			term:        "address",
+			expected:    "addresses",
+		},
+		{
+			description: "PluralNoun string ending with ch",
+			term:        "batch",
+			expected:    "batches",
+		},
+		{
+			description: "PluralNoun string ending with ex",
+			term:        "index",
+			expected:    "indices",
+		},
+		{
+			description: "PluralNoun plural string",
+			term:        "settings",
+			expected:    "settings",
+		},
+		{
+			description: "PluralNoun string ending with y",
+			term:        "policy",
+			expected:    "policies",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := PluralNoun(tc.term), tc.expected; got != want {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestStringSingular(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		term        string
+		expected    string
+	}{
+		{
+			description: "Singular normal string",
+			term:        "projects",
+			expected:    "project",
+		},
+		{
+			description: "Singular string ending with ies",
+			term:        "policies",
+			expected:    "policy",
+		},
+		{
+			description: "Singular camelcase string ending with ies",
+			term:        "accessPolicies",
+			expected:    "accessPolicy",
+		},
+		{
+			description: "Singular string ending with sses",
+			term:        "addresses",
+			expected:    "address",
+		},
+		{
+			description: "Singular string ending with ches",
+			term:        "batches",
+			expected:    "batch",
+		},
+		{
+			description: "Singular string ending with shes",
+			term:        "meshes",
+			expected:    "mesh",
+		},
+		{
+			description: "Singular string ending with xes",
+			term:        "boxes",
+			expected:    "box",
+		},
+		{
+			description: "Singular string ending with ices",
+			term:        "indices",
+			expected:    "index",
+		},
+		{
+			description: "Singular string ending with ses",
+			term:        "databases",
+			expected:    "database",
+		},
+		{
+			description: "Singular singular string ending with ss",
+			term:        "address",
+			expected:    "address",
+		},
+		{
+			description: "Singular singular string ending with us",
+			term:        "status",
+			expected:    "status",
+		},
+		{
+			description: "Singular singular string",
+			term:        "instance",
+			expected:    "instance",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := Singular(tc.term), tc.expected; got != want {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
 func TestStringFirstSentence(t *testing.T) {
 	t.Parallel()
 
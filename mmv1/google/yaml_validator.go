@@ -14,16 +14,24 @@
 package google
 
 import (
-	"log"
-
 	"gopkg.in/yaml.v2"
 )
 
 // A helper class to validate contents coming from YAML files.
-type YamlValidator struct{}
+type YamlValidator struct {
+	// Collects parse errors. If nil, the first parse error is fatal.
+	Diagnostics *Diagnostics
+}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+// Unmarshals content into obj, reporting every problem found to the
+// validator's Diagnostics. Returns the parse error, if any, so callers can
+// skip further processing of a partially populated obj.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	if err := yaml.UnmarshalStrict(content, obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+		v.Diagnostics.addYamlError(yamlPath, err)
+		return err
 	}
+
+	v.Diagnostics.indexPositions(yamlPath, content)
+	return nil
 }
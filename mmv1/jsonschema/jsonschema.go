@@ -0,0 +1,238 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema builds JSON Schema documents for product.yaml and
+// resource YAML files by reflecting over the api structs, so that editors can
+// autocomplete and validate them.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Directories, relative to mmv1, whose struct field comments are used as
+// schema descriptions.
+var sourceDirs = []string{"api", "api/product", "api/resource"}
+
+// Builds JSON Schema documents from Go types, following the same field
+// naming rules as yaml.v2.
+type Generator struct {
+	// Field doc comments keyed by "package.Type.Field", eg: api.Resource.Name
+	descriptions map[string]string
+
+	defs map[string]any
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		descriptions: make(map[string]string),
+	}
+}
+
+// Reads struct field comments from the Go files in dir to use as property
+// descriptions.
+func (g *Generator) LoadDescriptions(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range structType.Fields.List {
+				doc := field.Doc.Text()
+				if doc == "" {
+					doc = field.Comment.Text()
+				}
+				if doc == "" {
+					continue
+				}
+				for _, name := range field.Names {
+					key := fmt.Sprintf("%s.%s.%s", f.Name.Name, typeSpec.Name.Name, name.Name)
+					g.descriptions[key] = strings.TrimSpace(doc)
+				}
+			}
+			return false
+		})
+	}
+	return nil
+}
+
+// Returns a schema document validating YAML that unmarshals into root.
+func (g *Generator) Schema(title string, root reflect.Type) map[string]any {
+	g.defs = make(map[string]any)
+	schema := g.typeSchema(root)
+	schema["$schema"] = draft
+	schema["title"] = title
+	schema["$defs"] = g.defs
+	return schema
+}
+
+func (g *Generator) typeSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{
+			"type":  "array",
+			"items": g.typeSchema(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.typeSchema(t.Elem()),
+		}
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return map[string]any{"type": "object"}
+		}
+		name := t.String()
+		if _, ok := g.defs[name]; !ok {
+			// Reserve the name first so recursive types terminate
+			g.defs[name] = nil
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": fmt.Sprintf("#/$defs/%s", name)}
+	default:
+		// interface{} values such as default_value accept anything
+		return map[string]any{}
+	}
+}
+
+func (g *Generator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	g.addFields(t, properties)
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		// YAML files are read with yaml.UnmarshalStrict, so unknown keys fail
+		"additionalProperties": false,
+	}
+}
+
+func (g *Generator) addFields(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+		if inline {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			g.addFields(fieldType, properties)
+			continue
+		}
+
+		schema := g.typeSchema(field.Type)
+		if description, ok := g.descriptions[fmt.Sprintf("%s.%s", t.String(), field.Name)]; ok {
+			schema["description"] = description
+		}
+		properties[name] = schema
+	}
+}
+
+// Returns the key yaml.v2 uses for field: the name from the yaml tag, or
+// the lowercased field name when the tag does not set one.
+func yamlKey(field reflect.StructField) (name string, inline, skip bool) {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	for _, flag := range parts[1:] {
+		if flag == "inline" {
+			return "", true, false
+		}
+	}
+
+	if parts[0] != "" {
+		return parts[0], false, false
+	}
+	return strings.ToLower(field.Name), false, false
+}
+
+// Writes product.schema.json and resource.schema.json to dir.
+func Write(dir string) error {
+	g := NewGenerator()
+	for _, sourceDir := range sourceDirs {
+		if err := g.LoadDescriptions(sourceDir); err != nil {
+			return fmt.Errorf("reading descriptions from %s: %w", sourceDir, err)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	documents := map[string]map[string]any{
+		"product.schema.json":  g.Schema("MMv1 product.yaml", reflect.TypeOf(api.Product{})),
+		"resource.schema.json": g.Schema("MMv1 resource YAML", reflect.TypeOf(api.Resource{})),
+	}
+	for fileName, document := range documents {
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, fileName), append(content, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
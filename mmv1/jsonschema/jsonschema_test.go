@@ -0,0 +1,146 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestSchemaFieldNames(t *testing.T) {
+	t.Parallel()
+
+	g := NewGenerator()
+	schema := g.Schema("resource", reflect.TypeOf(api.Resource{}))
+	defs := schema["$defs"].(map[string]any)
+
+	cases := []struct {
+		description string
+		def         string
+		property    string
+		expected    bool
+	}{
+		{
+			description: "yaml tag name is used",
+			def:         "api.Resource",
+			property:    "update_mask",
+			expected:    true,
+		},
+		{
+			description: "untagged fields are lowercased",
+			def:         "api.Resource",
+			property:    "properties",
+			expected:    true,
+		},
+		{
+			description: "fields tagged with - are skipped",
+			def:         "api.Resource",
+			property:    "productmetadata",
+			expected:    false,
+		},
+		{
+			description: "inline struct fields are flattened",
+			def:         "api.Async",
+			property:    "check_response_func_existence",
+			expected:    true,
+		},
+		{
+			description: "resource structs are included",
+			def:         "resource.Sweeper",
+			property:    "url_substitutions",
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			def, ok := defs[tc.def].(map[string]any)
+			if !ok {
+				t.Fatalf("missing definition %s", tc.def)
+			}
+			_, got := def["properties"].(map[string]any)[tc.property]
+			if got != tc.expected {
+				t.Errorf("expected property %s in %s to exist: %v, got %v", tc.property, tc.def, tc.expected, got)
+			}
+		})
+	}
+}
+
+// Every key used in the checked-in YAML files must be allowed by the schema.
+func TestSchemaAcceptsProducts(t *testing.T) {
+	g := NewGenerator()
+	productSchema := g.Schema("product", reflect.TypeOf(api.Product{}))
+	resourceSchema := g.Schema("resource", reflect.TypeOf(api.Resource{}))
+
+	files, err := filepath.Glob("../products/*/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no product files found")
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			t.Fatalf("cannot parse %s: %v", file, err)
+		}
+
+		schema := resourceSchema
+		if filepath.Base(file) == "product.yaml" {
+			schema = productSchema
+		}
+		for _, problem := range unknownKeys(doc.Content[0], schema, schema["$defs"].(map[string]any), "") {
+			t.Errorf("%s: %s", file, problem)
+		}
+	}
+}
+
+func unknownKeys(node *yaml.Node, schema, defs map[string]any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	}
+
+	var problems []string
+	switch node.Kind {
+	case yaml.MappingNode:
+		properties, hasProperties := schema["properties"].(map[string]any)
+		additional, hasAdditional := schema["additionalProperties"].(map[string]any)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			childPath := fmt.Sprintf("%s.%s", path, key)
+			switch {
+			case hasProperties:
+				child, ok := properties[key].(map[string]any)
+				if !ok {
+					problems = append(problems, fmt.Sprintf("line %d: key %s is not in the schema", node.Content[i].Line, childPath))
+					continue
+				}
+				problems = append(problems, unknownKeys(value, child, defs, childPath)...)
+			case hasAdditional:
+				problems = append(problems, unknownKeys(value, additional, defs, childPath)...)
+			}
+		}
+	case yaml.SequenceNode:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for _, item := range node.Content {
+				problems = append(problems, unknownKeys(item, items, defs, path+"[]")...)
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint checks loaded products and resources for YAML that compiles
+// but is likely to generate surprising providers. Unlike Validate(), lint
+// rules never stop generation.
+package lint
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Records a finding against the property at lineage. An empty lineage
+// reports against the product or resource as a whole.
+type Reporter func(lineage, format string, a ...any)
+
+// A single lint check. At least one of CheckProduct and CheckResource must
+// be set.
+type Rule struct {
+	// Unique kebab-case name used to enable or disable the rule.
+	Name string
+
+	Description string
+
+	Severity Severity
+
+	CheckProduct func(p *api.Product, report Reporter)
+
+	CheckResource func(r *api.Resource, report Reporter)
+}
+
+var registry = make(map[string]Rule)
+
+// Adds a rule to the registry. Rules are expected to register themselves
+// from an init function.
+func Register(rule Rule) {
+	if rule.Name == "" {
+		log.Fatalf("Missing `Name` for lint rule")
+	}
+	if rule.CheckProduct == nil && rule.CheckResource == nil {
+		log.Fatalf("Lint rule %s does not check anything", rule.Name)
+	}
+	if _, ok := registry[rule.Name]; ok {
+		log.Fatalf("Lint rule %s is registered more than once", rule.Name)
+	}
+	registry[rule.Name] = rule
+}
+
+// Returns every registered rule, sorted by name.
+func Rules() []Rule {
+	rules := make([]Rule, 0, len(registry))
+	for _, rule := range registry {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// Runs a set of registered rules over products.
+type Linter struct {
+	rules []Rule
+}
+
+// Builds a Linter from the registered rules. If enabled is non-empty only
+// the named rules run; any rule in disabled is then skipped.
+func NewLinter(enabled, disabled []string) (*Linter, error) {
+	for _, names := range [][]string{enabled, disabled} {
+		for _, name := range names {
+			if _, ok := registry[name]; !ok {
+				return nil, fmt.Errorf("unknown lint rule %q", name)
+			}
+		}
+	}
+
+	l := &Linter{}
+	for _, rule := range Rules() {
+		if len(enabled) > 0 && !slices.Contains(enabled, rule.Name) {
+			continue
+		}
+		if slices.Contains(disabled, rule.Name) {
+			continue
+		}
+		l.rules = append(l.rules, rule)
+	}
+	return l, nil
+}
+
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// Runs every enabled rule over products and their resources, reporting
+// findings to diags.
+func (l *Linter) Run(products []*api.Product, diags *google.Diagnostics) {
+	for _, p := range products {
+		l.RunProduct(p, diags)
+	}
+}
+
+func (l *Linter) RunProduct(p *api.Product, diags *google.Diagnostics) {
+	for _, rule := range l.rules {
+		if rule.CheckProduct != nil {
+			rule.CheckProduct(p, reporter(rule, p.SourceYamlFile, diags))
+		}
+		if rule.CheckResource == nil {
+			continue
+		}
+		for _, r := range p.Objects {
+			rule.CheckResource(r, reporter(rule, r.SourceYamlFile, diags))
+		}
+	}
+}
+
+func reporter(rule Rule, yamlPath string, diags *google.Diagnostics) Reporter {
+	return func(lineage, format string, a ...any) {
+		diags.Report(google.Diagnostic{
+			File:     yamlPath,
+			Lineage:  lineage,
+			Message:  fmt.Sprintf(format, a...),
+			Severity: string(rule.Severity),
+			Rule:     rule.Name,
+		})
+	}
+}
+
+// Parses a comma-separated list of rule names as passed on the command line.
+func ParseRuleNames(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func init() {
+	Register(Rule{
+		Name:          "update-mask-without-updatable-properties",
+		Description:   "`update_mask: true` is set but every property is immutable or output-only, so no update is ever sent.",
+		Severity:      SeverityWarning,
+		CheckResource: checkUpdateMaskWithoutUpdatableProperties,
+	})
+	Register(Rule{
+		Name:          "unknown-field-reference",
+		Description:   "An `exactly_one_of`, `at_least_one_of`, `conflicts` or `required_with` entry does not match any property, so it is silently dropped from the schema.",
+		Severity:      SeverityWarning,
+		CheckResource: checkUnknownFieldReference,
+	})
+	Register(Rule{
+		Name:          "enum-case-collision",
+		Description:   "Two values of an enum only differ by case.",
+		Severity:      SeverityWarning,
+		CheckResource: checkEnumCaseCollision,
+	})
+	Register(Rule{
+		Name:          "immutable-with-update-url",
+		Description:   "`immutable: true` is set on a resource that also sets `update_url`, which is never used.",
+		Severity:      SeverityWarning,
+		CheckResource: checkImmutableWithUpdateUrl,
+	})
+}
+
+func checkUpdateMaskWithoutUpdatableProperties(r *api.Resource, report Reporter) {
+	if !r.UpdateMask {
+		return
+	}
+
+	for _, p := range r.SettableProperties() {
+		if p.IsA("Fingerprint") {
+			continue
+		}
+		if !p.IsForceNew() {
+			return
+		}
+	}
+
+	report("", "resource %s sets `update_mask: true` but has no updatable properties", r.Name)
+}
+
+func checkUnknownFieldReference(r *api.Resource, report Reporter) {
+	for _, p := range allProperties(r.AllUserProperties()) {
+		references := map[string][]string{
+			"exactly_one_of":  p.ExactlyOneOf,
+			"at_least_one_of": p.AtLeastOneOf,
+			"conflicts":       p.Conflicts,
+			"required_with":   p.RequiredWith,
+		}
+		for _, key := range []string{"exactly_one_of", "at_least_one_of", "conflicts", "required_with"} {
+			for _, reference := range references[key] {
+				if p.GetPropertySchemaPath(reference) == "" {
+					report(p.Lineage(), "`%s` entry %q does not match any property", key, reference)
+				}
+			}
+		}
+	}
+}
+
+func checkEnumCaseCollision(r *api.Resource, report Reporter) {
+	for _, p := range allProperties(r.AllUserProperties()) {
+		enumValues := append([]string{}, p.EnumValues...)
+		if p.IsA("Array") && p.ItemType != nil {
+			enumValues = append(enumValues, p.ItemType.EnumValues...)
+		}
+
+		seen := make(map[string]string)
+		for _, v := range enumValues {
+			normalized := strings.ToUpper(v)
+			if previous, ok := seen[normalized]; ok && previous != v {
+				report(p.Lineage(), "enum values %q and %q only differ by case", previous, v)
+				continue
+			}
+			seen[normalized] = v
+		}
+	}
+}
+
+func checkImmutableWithUpdateUrl(r *api.Resource, report Reporter) {
+	if r.Immutable && r.UpdateUrl != "" {
+		report("", "resource %s sets both `immutable: true` and `update_url: %s`", r.Name, r.UpdateUrl)
+	}
+}
+
+// Returns props and all of their descendants, including the children of
+// flattened objects.
+func allProperties(props []*api.Type) []*api.Type {
+	var all []*api.Type
+	for _, p := range props {
+		all = append(all, p)
+		all = append(all, allProperties(p.NestedProperties())...)
+	}
+	return all
+}
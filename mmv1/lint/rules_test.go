@@ -0,0 +1,160 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func newTestResource(r *api.Resource) *api.Resource {
+	r.Name = "Widget"
+	r.BaseUrl = "projects/{{project}}/widgets"
+	r.SetDefault(&api.Product{Name: "Test"})
+	return r
+}
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		rule        string
+		obj         *api.Resource
+		expected    []string
+	}{
+		{
+			description: "update mask with only immutable properties",
+			rule:        "update-mask-without-updatable-properties",
+			obj: newTestResource(&api.Resource{
+				UpdateMask: true,
+				Properties: []*api.Type{
+					{Name: "foo", Type: "String", Immutable: true},
+					{Name: "bar", Type: "String", Output: true},
+				},
+			}),
+			expected: []string{""},
+		},
+		{
+			description: "update mask with an updatable property",
+			rule:        "update-mask-without-updatable-properties",
+			obj: newTestResource(&api.Resource{
+				UpdateMask: true,
+				Properties: []*api.Type{
+					{Name: "foo", Type: "String", Immutable: true},
+					{Name: "bar", Type: "String"},
+				},
+			}),
+		},
+		{
+			description: "exactly_one_of pointing to a missing property",
+			rule:        "unknown-field-reference",
+			obj: newTestResource(&api.Resource{
+				Properties: []*api.Type{
+					{Name: "foo", Type: "String", ExactlyOneOf: []string{"foo", "bar"}},
+					{Name: "baz", Type: "String", ExactlyOneOf: []string{"foo", "baz"}},
+				},
+			}),
+			expected: []string{"foo"},
+		},
+		{
+			description: "nested exactly_one_of using the full path",
+			rule:        "unknown-field-reference",
+			obj: newTestResource(&api.Resource{
+				Properties: []*api.Type{
+					{
+						Name: "parent",
+						Type: "NestedObject",
+						Properties: []*api.Type{
+							{Name: "foo", Type: "String", ExactlyOneOf: []string{"parent.0.foo", "parent.0.bar"}},
+							{Name: "bar", Type: "String", ExactlyOneOf: []string{"foo", "bar"}},
+						},
+					},
+				},
+			}),
+			expected: []string{"parent.bar", "parent.bar"},
+		},
+		{
+			description: "enum values differing by case",
+			rule:        "enum-case-collision",
+			obj: newTestResource(&api.Resource{
+				Properties: []*api.Type{
+					{Name: "mode", Type: "Enum", EnumValues: []string{"AUTO", "MANUAL", "auto"}},
+					{Name: "other", Type: "Enum", EnumValues: []string{"AUTO", "MANUAL"}},
+				},
+			}),
+			expected: []string{"mode"},
+		},
+		{
+			description: "immutable resource with update_url",
+			rule:        "immutable-with-update-url",
+			obj: newTestResource(&api.Resource{
+				Immutable: true,
+				UpdateUrl: "projects/{{project}}/widgets/{{name}}",
+				Properties: []*api.Type{
+					{Name: "foo", Type: "String"},
+				},
+			}),
+			expected: []string{""},
+		},
+		{
+			description: "mutable resource with update_url",
+			rule:        "immutable-with-update-url",
+			obj: newTestResource(&api.Resource{
+				UpdateUrl: "projects/{{project}}/widgets/{{name}}",
+				Properties: []*api.Type{
+					{Name: "foo", Type: "String"},
+				},
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			linter, err := NewLinter([]string{tc.rule}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			diags := google.NewDiagnostics()
+			linter.RunProduct(&api.Product{Name: "Test", Objects: []*api.Resource{tc.obj}}, diags)
+
+			var got []string
+			for _, d := range diags.All() {
+				if d.Rule != tc.rule {
+					t.Errorf("expected finding from rule %s, got %s", tc.rule, d.Rule)
+				}
+				got = append(got, d.Lineage)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected findings at %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewLinter(t *testing.T) {
+	t.Parallel()
+
+	linter, err := NewLinter(nil, []string{"enum-case-collision"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, rule := range linter.Rules() {
+		if rule.Name == "enum-case-collision" {
+			t.Errorf("expected enum-case-collision to be disabled")
+		}
+	}
+	if got, want := len(linter.Rules()), len(Rules())-1; got != want {
+		t.Errorf("expected %d rules, got %d", want, got)
+	}
+
+	if _, err := NewLinter([]string{"no-such-rule"}, nil); err == nil {
+		t.Errorf("expected an error for an unknown rule")
+	}
+}
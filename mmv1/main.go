@@ -17,12 +17,24 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/discovery_generate"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/drift"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/jsonschema"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/lint"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
 )
 
 var wg sync.WaitGroup
 
+// Collects YAML parse and validation errors across all products so they can
+// be reported together at the end of the run.
+var diagnostics = google.NewDiagnostics()
+
+// Holds the rendered files in --diff mode
+var dryRun *provider.DryRun
+
 // TODO rewrite: additional flags
 
 // Example usage: --output $GOPATH/src/github.com/terraform-providers/terraform-provider-google-beta
@@ -31,28 +43,78 @@ var outputPath = flag.String("output", "", "path to output generated files to")
 // Example usage: --version beta
 var version = flag.String("version", "", "optional version name. If specified, this version is preferred for resource generation when applicable")
 
-var overrideDirectory = flag.String("overrides", "", "directory containing yaml overrides")
+// Example usage: --overrides ../org-overrides,../team-overrides
+var overrideDirectory = flag.String("overrides", "", "comma-separated directories containing yaml overrides. Each directory is merged over the products and the directories before it")
+
+// Parsed from --overrides, relative to the magic-modules directory
+var overrideDirectories []string
 
 var product = flag.String("product", "", "optional product name. If specified, the resources under the specific product will be generated. Otherwise, resources under all products will be generated.")
 
-var resourceToGenerate = flag.String("resource", "", "optional resource name. Limits generation to the specified resource within a particular product.")
+var resourceToGenerate = flag.String("resource", "", "optional resource name. Limits generation, lint and drift to the specified resource within a particular product.")
 
 var doNotGenerateCode = flag.Bool("no-code", false, "do not generate code")
 
 var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
-var forceProvider = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
+var forceProvider = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used. See --list-providers")
+
+var listProviders = flag.Bool("list-providers", false, "print the available providers and exit")
 
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
+var discoveryGenerate = flag.Bool("discovery-generate", false, "Generate MMv1 YAML from the *.discovery.json files in the discovery directory (Experimental)")
+
 // Example usage: --yaml
 var yamlMode = flag.Bool("yaml", false, "copy text over from ruby yaml to go yaml")
 
+var enableCache = flag.Bool("cache", false, "skip resources whose inputs are unchanged since the last --cache run into --output, tracked in a .mmv1_generation_cache.json file in --output")
+
+// Example usage: --diff --diff-format json
+var diffMode = flag.Bool("diff", false, "render files in memory and print how they differ from the files in --output instead of writing them. Deleted files are reported if a previous --cache run into --output recorded them")
+
+var diffFormat = flag.String("diff-format", "unified", "diff mode only: `unified` for a unified diff, or `json` for a list of added, changed and deleted files")
+
 var showImportDiffs = flag.Bool("show-import-diffs", false, "write go import diffs to stdout")
 
+var diagnosticsJson = flag.Bool("diagnostics-json", false, "write YAML parse and validation errors to stdout as JSON")
+
+// Example usage: --emit-schema ../.schemas
+var emitSchema = flag.String("emit-schema", "", "directory to write JSON Schemas for product.yaml and resource YAML files to. No code is generated.")
+
+// Example usage: lint --disable-rules enum-case-collision
+var enableLintRules = flag.String("enable-rules", "", "lint mode only: comma-separated lint rules to run. Defaults to all rules")
+
+var disableLintRules = flag.String("disable-rules", "", "lint mode only: comma-separated lint rules to skip")
+
+var listLintRules = flag.Bool("list-rules", false, "lint mode only: print the available lint rules and exit")
+
+// Example usage: drift --api discovery_generate/discovery
+var apiDescription = flag.String("api", "", "drift mode only: OpenAPI or discovery file, or a directory of them, to compare resources with")
+
 func main() {
 
-	flag.Parse()
+	provider.RegisterFlags(flag.CommandLine)
+
+	// `mmv1 lint [flags]` loads products and runs lint rules without
+	// generating anything. `mmv1 drift [flags]` compares them with API
+	// descriptions instead.
+	lintMode := len(os.Args) > 1 && os.Args[1] == "lint"
+	driftMode := len(os.Args) > 1 && os.Args[1] == "drift"
+	if lintMode || driftMode {
+		flag.CommandLine.Parse(os.Args[2:])
+	} else {
+		flag.Parse()
+	}
+
+	overrideDirectories = parseOverrideDirectories(*overrideDirectory)
+
+	if *listProviders {
+		for _, registration := range provider.Providers() {
+			fmt.Printf("%-15s %s\n", registration.Name, registration.Description)
+		}
+		return
+	}
 
 	if *openapiGenerate {
 		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
@@ -60,6 +122,28 @@ func main() {
 		return
 	}
 
+	if *discoveryGenerate {
+		parser := discovery_generate.NewDiscoveryParser("discovery_generate/discovery", "products")
+		parser.Run()
+		return
+	}
+
+	if *emitSchema != "" {
+		if err := jsonschema.Write(*emitSchema); err != nil {
+			log.Fatalf("Cannot write JSON Schema: %v", err)
+		}
+		log.Printf("Wrote JSON Schema to %s", *emitSchema)
+		return
+	}
+
+	if lintMode {
+		os.Exit(runLint())
+	}
+
+	if driftMode {
+		os.Exit(runDrift())
+	}
+
 	if outputPath == nil || *outputPath == "" {
 		log.Printf("No output path specified, exiting")
 		return
@@ -70,6 +154,16 @@ func main() {
 		*version = "ga"
 	}
 
+	if *diffMode {
+		if *forceProvider != "" && *forceProvider != provider.DefaultProvider {
+			log.Fatalf("--diff is only supported for the default terraform provider")
+		}
+		if *diffFormat != "unified" && *diffFormat != "json" {
+			log.Fatalf("Unknown --diff-format %q, expected unified or json", *diffFormat)
+		}
+		dryRun = provider.EnableDryRun()
+	}
+
 	var generateCode = !*doNotGenerateCode
 	var generateDocs = !*doNotGenerateDocs
 	var productsToGenerate []string
@@ -81,47 +175,7 @@ func main() {
 		productsToGenerate = []string{productToGenerate}
 	}
 
-	var allProductFiles []string = make([]string, 0)
-
-	files, err := filepath.Glob("products/**/product.yaml")
-	if err != nil {
-		panic(err)
-	}
-	for _, filePath := range files {
-		dir := filepath.Dir(filePath)
-		allProductFiles = append(allProductFiles, fmt.Sprintf("products/%s", filepath.Base(dir)))
-	}
-
-	if *overrideDirectory != "" {
-		log.Printf("Using override directory %s", *overrideDirectory)
-
-		// Normalize override dir to a path that is relative to the magic-modules directory
-		// This is needed for templates that concatenate pwd + override dir + path
-		if filepath.IsAbs(*overrideDirectory) {
-			wd, err := os.Getwd()
-			if err != nil {
-				panic(err)
-			}
-			*overrideDirectory, err = filepath.Rel(wd, *overrideDirectory)
-			log.Printf("Override directory normalized to relative path %s", *overrideDirectory)
-		}
-
-		overrideFiles, err := filepath.Glob(fmt.Sprintf("%s/products/**/product.yaml", *overrideDirectory))
-		if err != nil {
-			panic(err)
-		}
-		for _, filePath := range overrideFiles {
-			product, err := filepath.Rel(*overrideDirectory, filePath)
-			if err != nil {
-				panic(err)
-			}
-			dir := filepath.Dir(product)
-			productFile := fmt.Sprintf("products/%s", filepath.Base(dir))
-			if !slices.Contains(allProductFiles, productFile) {
-				allProductFiles = append(allProductFiles, productFile)
-			}
-		}
-	}
+	allProductFiles := listProductFiles()
 
 	if allProducts {
 		productsToGenerate = allProductFiles
@@ -134,21 +188,39 @@ func main() {
 	startTime := time.Now()
 	providerName := "default (terraform)"
 	if *forceProvider != "" {
+		if _, ok := provider.Lookup(*forceProvider); !ok {
+			log.Fatalf("Unknown provider %q, see --list-providers", *forceProvider)
+		}
 		providerName = *forceProvider
 	}
 	log.Printf("Generating MM output to '%s'", *outputPath)
 	log.Printf("Building %s version", *version)
 	log.Printf("Building %s provider", providerName)
 
+	if *diffMode {
+		// Every file is rendered to be compared with --output, and the cache
+		// of a previous --cache run tells which files are no longer generated.
+		if !provider.EnableGenerationManifest(*outputPath) {
+			log.Printf("No %s in %s, deleted files are only reported after a --cache run", provider.GenerationCacheFile, *outputPath)
+		}
+	} else if *enableCache {
+		provider.EnableGenerationCache(*outputPath)
+	}
+
 	productsForVersionChannel := make(chan *api.Product, len(allProductFiles))
 	for _, productFile := range allProductFiles {
 		wg.Add(1)
-		go GenerateProduct(productFile, productsForVersionChannel, startTime, productsToGenerate, *resourceToGenerate, *overrideDirectory, generateCode, generateDocs)
+		go GenerateProduct(productFile, productsForVersionChannel, startTime, productsToGenerate, *resourceToGenerate, overrideDirectories, generateCode, generateDocs)
 	}
 	wg.Wait()
 
 	close(productsForVersionChannel)
 
+	if diagnostics.HasErrors() {
+		reportDiagnostics()
+		os.Exit(1)
+	}
+
 	var productsForVersion []*api.Product
 	for p := range productsForVersionChannel {
 		productsForVersion = append(productsForVersion, p)
@@ -167,124 +239,239 @@ func main() {
 	}
 
 	provider.FixImports(*outputPath, *showImportDiffs)
-}
 
-func GenerateProduct(productName string, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
-	defer wg.Done()
+	if *diffMode {
+		writeDiff()
+		return
+	}
 
-	productYamlPath := path.Join(productName, "product.yaml")
+	if err := provider.SaveGenerationCache(); err != nil {
+		log.Printf("Cannot write generation cache: %v", err)
+	}
+}
 
-	var productOverridePath string
-	if overrideDirectory != "" {
-		productOverridePath = filepath.Join(overrideDirectory, productName, "product.yaml")
+// Prints how the files rendered in memory differ from --output.
+func writeDiff() {
+	var err error
+	if *diffFormat == "json" {
+		err = dryRun.WriteChanges(os.Stdout, *outputPath)
+	} else {
+		err = dryRun.WriteDiff(os.Stdout, *outputPath)
 	}
+	if err != nil {
+		log.Fatalf("Cannot write diff: %v", err)
+	}
+}
 
-	_, baseProductErr := os.Stat(productYamlPath)
-	baseProductExists := !errors.Is(baseProductErr, os.ErrNotExist)
+// Returns the directory of every product, including products that only
+// exist in an override directory.
+func listProductFiles() []string {
+	var allProductFiles []string = make([]string, 0)
 
-	_, overrideProductErr := os.Stat(productOverridePath)
-	overrideProductExists := !errors.Is(overrideProductErr, os.ErrNotExist)
+	files, err := filepath.Glob("products/**/product.yaml")
+	if err != nil {
+		panic(err)
+	}
+	for _, filePath := range files {
+		dir := filepath.Dir(filePath)
+		allProductFiles = append(allProductFiles, fmt.Sprintf("products/%s", filepath.Base(dir)))
+	}
 
-	if !(baseProductExists || overrideProductExists) {
-		log.Fatalf("%s does not contain a product.yaml file", productName)
+	for _, overrideDir := range overrideDirectories {
+		overrideFiles, err := filepath.Glob(fmt.Sprintf("%s/products/**/product.yaml", overrideDir))
+		if err != nil {
+			panic(err)
+		}
+		for _, filePath := range overrideFiles {
+			product, err := filepath.Rel(overrideDir, filePath)
+			if err != nil {
+				panic(err)
+			}
+			dir := filepath.Dir(product)
+			productFile := fmt.Sprintf("products/%s", filepath.Base(dir))
+			if !slices.Contains(allProductFiles, productFile) {
+				allProductFiles = append(allProductFiles, productFile)
+			}
+		}
 	}
 
-	productApi := &api.Product{}
+	return allProductFiles
+}
 
-	if overrideProductExists {
-		if baseProductExists {
-			api.Compile(productYamlPath, productApi, overrideDirectory)
-			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct, overrideDirectory)
+// Splits the value of --overrides into its directories, in the order they
+// are merged.
+func parseOverrideDirectories(value string) []string {
+	var dirs []string
+	for _, dir := range strings.Split(value, ",") {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			continue
+		}
+		log.Printf("Using override directory %s", dir)
 
-			api.Merge(reflect.ValueOf(productApi), reflect.ValueOf(*overrideApiProduct))
-		} else {
-			api.Compile(productOverridePath, productApi, overrideDirectory)
+		// Normalize override dir to a path that is relative to the magic-modules directory
+		// This is needed for templates that concatenate pwd + override dir + path
+		if filepath.IsAbs(dir) {
+			wd, err := os.Getwd()
+			if err != nil {
+				panic(err)
+			}
+			dir, err = filepath.Rel(wd, dir)
+			if err != nil {
+				panic(err)
+			}
+			log.Printf("Override directory normalized to relative path %s", dir)
 		}
-	} else {
-		api.Compile(productYamlPath, productApi, overrideDirectory)
+		dirs = append(dirs, dir)
 	}
+	return dirs
+}
 
-	var resources []*api.Resource = make([]*api.Resource, 0)
+// Loads every product at the requested version and runs the enabled lint
+// rules over it, limited to --resource if set. Returns the process exit code:
+// non-zero if a product could not be loaded or an error-severity rule
+// reported a finding.
+func runLint() int {
+	linter, err := lint.NewLinter(lint.ParseRuleNames(*enableLintRules), lint.ParseRuleNames(*disableLintRules))
+	if err != nil {
+		log.Printf("%v", err)
+		return 2
+	}
 
-	if !productApi.ExistsAtVersionOrLower(*version) {
-		log.Printf("%s does not have a '%s' version, skipping", productName, *version)
-		return
+	if *listLintRules {
+		for _, rule := range linter.Rules() {
+			fmt.Printf("%-45s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+		return 0
 	}
 
-	resourceFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productName))
-	if err != nil {
-		log.Fatalf("Cannot get resources files: %v", err)
+	if version == nil || *version == "" {
+		*version = "ga"
 	}
-	// Base resource loop
-	for _, resourceYamlPath := range resourceFiles {
-		if filepath.Base(resourceYamlPath) == "product.yaml" || filepath.Ext(resourceYamlPath) != ".yaml" {
-			continue
-		}
 
-		if overrideDirectory != "" {
-			// skip if resource will be merged in the override loop
-			resourceOverridePath := filepath.Join(overrideDirectory, resourceYamlPath)
-			_, overrideResourceErr := os.Stat(resourceOverridePath)
-			overrideResourceExists := !errors.Is(overrideResourceErr, os.ErrNotExist)
-			if overrideResourceExists {
-				continue
+	productsToLint := listProductFiles()
+	if *product != "" {
+		productsToLint = []string{fmt.Sprintf("products/%s", *product)}
+	}
+
+	for _, productName := range productsToLint {
+		productDiagnostics := google.NewDiagnostics()
+		productApi := LoadProduct(productName, overrideDirectories, productDiagnostics)
+		if productApi != nil {
+			if *resourceToGenerate != "" {
+				productApi.Objects = slices.DeleteFunc(productApi.Objects, func(r *api.Resource) bool {
+					return r.Name != *resourceToGenerate
+				})
 			}
+			linter.RunProduct(productApi, productDiagnostics)
 		}
+		diagnostics.Append(productDiagnostics)
+	}
 
-		resource := &api.Resource{}
-		api.Compile(resourceYamlPath, resource, overrideDirectory)
-		resource.SourceYamlFile = resourceYamlPath
+	reportDiagnostics()
+	if diagnostics.HasErrors() {
+		return 1
+	}
+	return 0
+}
 
-		resource.TargetVersionName = *version
-		resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
-		resource.SetDefault(productApi)
-		resource.Validate()
-		resources = append(resources, resource)
+// Reports drift between resources and the API descriptions in --api. Exits
+// non-zero when any is found so that scheduled jobs can alert on it.
+func runDrift() int {
+	if *apiDescription == "" {
+		log.Printf("No API description specified, use --api")
+		return 2
+	}
+	apiResources, err := drift.Load(*apiDescription)
+	if err != nil {
+		log.Printf("Cannot read API description: %v", err)
+		return 2
 	}
 
-	// Override Resource Loop
-	if overrideDirectory != "" {
-		productOverrideDir := filepath.Dir(productOverridePath)
-		overrideFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productOverrideDir))
-		if err != nil {
-			log.Fatalf("Cannot get override files: %v", err)
+	if version == nil || *version == "" {
+		*version = "ga"
+	}
+
+	productsToCheck := listProductFiles()
+	if *product != "" {
+		productsToCheck = []string{fmt.Sprintf("products/%s", *product)}
+	}
+
+	compared := 0
+	for _, productName := range productsToCheck {
+		productDiagnostics := google.NewDiagnostics()
+		productApi := LoadProduct(productName, overrideDirectories, productDiagnostics)
+		if productApi == nil {
+			diagnostics.Append(productDiagnostics)
+			continue
 		}
-		for _, overrideYamlPath := range overrideFiles {
-			if filepath.Base(overrideYamlPath) == "product.yaml" || filepath.Ext(overrideYamlPath) != ".yaml" {
+
+		baseUrl := productApi.VersionObjOrClosest(*version).BaseUrl
+		for _, r := range productApi.Objects {
+			if *resourceToGenerate != "" && r.Name != *resourceToGenerate {
 				continue
 			}
-
-			resource := &api.Resource{}
-
-			baseResourcePath := filepath.Join(productName, filepath.Base(overrideYamlPath))
-			_, baseResourceErr := os.Stat(baseResourcePath)
-			baseResourceExists := !errors.Is(baseResourceErr, os.ErrNotExist)
-			if baseResourceExists {
-				api.Compile(baseResourcePath, resource, overrideDirectory)
-				overrideResource := &api.Resource{}
-				api.Compile(overrideYamlPath, overrideResource, overrideDirectory)
-				api.Merge(reflect.ValueOf(resource), reflect.ValueOf(*overrideResource))
-			} else {
-				api.Compile(overrideYamlPath, resource, overrideDirectory)
+			apiResource := drift.Match(r, baseUrl, apiResources)
+			if apiResource == nil {
+				continue
 			}
+			compared++
+			drift.Compare(r, apiResource, func(rule, lineage, format string, a ...any) {
+				productDiagnostics.Report(google.Diagnostic{
+					File:     r.SourceYamlFile,
+					Lineage:  lineage,
+					Message:  fmt.Sprintf(format, a...),
+					Severity: "warning",
+					Rule:     rule,
+				})
+			})
+		}
+		diagnostics.Append(productDiagnostics)
+	}
+	log.Printf("Compared %d resource(s) with %s", compared, *apiDescription)
+
+	reportDiagnostics()
+	if len(diagnostics.All()) > 0 {
+		return 1
+	}
+	return 0
+}
 
-			resource.TargetVersionName = *version
-			resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
-			resource.SetDefault(productApi)
-			resource.Validate()
-			resources = append(resources, resource)
+func reportDiagnostics() {
+	if *diagnosticsJson {
+		if err := diagnostics.WriteJSON(os.Stdout); err != nil {
+			log.Fatalf("Cannot write diagnostics: %v", err)
 		}
+		return
+	}
 
-		// Sort resources by name
-		sort.Slice(resources, func(i, j int) bool {
-			return resources[i].Name < resources[j].Name
-		})
+	all := diagnostics.All()
+	if len(all) == 0 {
+		return
+	}
+	log.Printf("Found %d problem(s) in YAML files:", len(all))
+	for _, d := range all {
+		log.Print(d.String())
+	}
+}
+
+func GenerateProduct(productName string, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate string, overrideDirectories []string, generateCode, generateDocs bool) {
+	defer wg.Done()
 
+	// Problems are collected per product so that a product with errors is
+	// neither generated nor passed on to CompileCommonFiles.
+	productDiagnostics := google.NewDiagnostics()
+	defer diagnostics.Append(productDiagnostics)
+
+	productApi := LoadProduct(productName, overrideDirectories, productDiagnostics)
+	if productApi == nil {
+		return
 	}
 
-	productApi.Objects = resources
-	productApi.Validate()
+	if productDiagnostics.HasErrors() {
+		log.Printf("%s has errors, skipping generation", productName)
+		return
+	}
 
 	providerToGenerate := newProvider(*forceProvider, *version, productApi, startTime)
 	productsForVersionChannel <- productApi
@@ -299,17 +486,153 @@ func GenerateProduct(productName string, productsForVersionChannel chan *api.Pro
 	providerToGenerate.Generate(*outputPath, productName, resourceToGenerate, generateCode, generateDocs)
 }
 
+// Loads the product in productName and all of its resources, merging in
+// each override directory in order. Parse and validation problems are
+// reported to diags. Returns nil if the product could not be parsed or does
+// not exist at the requested version.
+func LoadProduct(productName string, overrideDirectories []string, diags *google.Diagnostics) *api.Product {
+	productLayers := yamlLayers(path.Join(productName, "product.yaml"), overrideDirectories)
+	if len(productLayers) == 0 {
+		diags.Errorf(productName, "", "%s does not contain a product.yaml file", productName)
+		return nil
+	}
+
+	productApi := &api.Product{}
+	if compileLayers(productLayers, productApi, overrideDirectories, diags) != nil {
+		return nil
+	}
+	productApi.SourceYamlFile = productLayers[0].path
+
+	var resources []*api.Resource = make([]*api.Resource, 0)
+
+	if !productApi.ExistsAtVersionOrLower(*version) {
+		log.Printf("%s does not have a '%s' version, skipping", productName, *version)
+		return nil
+	}
+
+	for _, fileName := range resourceFileNames(productName, overrideDirectories) {
+		resourceLayers := yamlLayers(filepath.Join(productName, fileName), overrideDirectories)
+
+		resource := &api.Resource{}
+		if compileLayers(resourceLayers, resource, overrideDirectories, diags) != nil {
+			continue
+		}
+		resource.SourceYamlFile = resourceLayers[0].path
+		if len(overrideDirectories) > 0 {
+			log.Printf("%s: %s from %s", productName, resource.Name, layerNames(resourceLayers))
+		}
+
+		resource.TargetVersionName = *version
+		resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
+		resource.SetDefault(productApi)
+		resource.Validate(diags)
+		resources = append(resources, resource)
+	}
+
+	if len(overrideDirectories) > 0 {
+		// Sort resources by name
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].Name < resources[j].Name
+		})
+	}
+
+	productApi.Objects = resources
+	productApi.Validate(diags)
+
+	return productApi
+}
+
+// A YAML file and the override directory it was read from. overrideDir is
+// empty for files in the products directory.
+type yamlLayer struct {
+	path        string
+	overrideDir string
+}
+
+// Returns every existing copy of relPath, eg: products/pubsub/Topic.yaml,
+// starting with the products directory and then each override directory in
+// order.
+func yamlLayers(relPath string, overrideDirectories []string) []yamlLayer {
+	var layers []yamlLayer
+	if _, err := os.Stat(relPath); !errors.Is(err, os.ErrNotExist) {
+		layers = append(layers, yamlLayer{path: relPath})
+	}
+	for _, overrideDir := range overrideDirectories {
+		overridePath := filepath.Join(overrideDir, relPath)
+		if _, err := os.Stat(overridePath); !errors.Is(err, os.ErrNotExist) {
+			layers = append(layers, yamlLayer{path: overridePath, overrideDir: overrideDir})
+		}
+	}
+	return layers
+}
+
+// Compiles the first layer into obj and merges every later layer over it.
+// {{override_path}} resolves to the override directory of the layer being
+// compiled, or to the last override directory for files in the products
+// directory.
+func compileLayers[T any](layers []yamlLayer, obj *T, overrideDirectories []string, diags *google.Diagnostics) error {
+	for i, layer := range layers {
+		overrideDir := layer.overrideDir
+		if overrideDir == "" && len(overrideDirectories) > 0 {
+			overrideDir = overrideDirectories[len(overrideDirectories)-1]
+		}
+
+		if i == 0 {
+			if err := api.Compile(layer.path, obj, overrideDir, diags); err != nil {
+				return err
+			}
+			continue
+		}
+
+		override := new(T)
+		if err := api.Compile(layer.path, override, overrideDir, diags); err != nil {
+			return err
+		}
+		api.Merge(reflect.ValueOf(obj), reflect.ValueOf(*override))
+	}
+	return nil
+}
+
+// Returns the names of the resource files of a product across the products
+// directory and every override directory, sorted.
+func resourceFileNames(productName string, overrideDirectories []string) []string {
+	var fileNames []string
+	for _, dir := range append([]string{""}, overrideDirectories...) {
+		resourceFiles, err := filepath.Glob(fmt.Sprintf("%s/*", filepath.Join(dir, productName)))
+		if err != nil {
+			log.Fatalf("Cannot get resources files: %v", err)
+		}
+		for _, resourceYamlPath := range resourceFiles {
+			fileName := filepath.Base(resourceYamlPath)
+			if fileName == "product.yaml" || filepath.Ext(fileName) != ".yaml" || slices.Contains(fileNames, fileName) {
+				continue
+			}
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+	return fileNames
+}
+
+// Describes where a merged YAML file came from for logs, eg:
+// products, ../org-overrides
+func layerNames(layers []yamlLayer) string {
+	var names []string
+	for _, layer := range layers {
+		if layer.overrideDir == "" {
+			names = append(names, "products")
+		} else {
+			names = append(names, layer.overrideDir)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Builds the provider selected with --provider for a product.
 func newProvider(providerName, version string, productApi *api.Product, startTime time.Time) provider.Provider {
-	switch providerName {
-	case "tgc":
-		return provider.NewTerraformGoogleConversion(productApi, version, startTime)
-	case "tgc_cai2hcl":
-		return provider.NewCaiToTerraformConversion(productApi, version, startTime)
-	case "tgc_next":
-		return provider.NewTerraformGoogleConversionNext(productApi, version, startTime)
-	case "oics":
-		return provider.NewTerraformOiCS(productApi, version, startTime)
-	default:
-		return provider.NewTerraform(productApi, version, startTime)
+	p, err := provider.New(providerName, productApi, version, startTime)
+	if err != nil {
+		log.Fatalf("%v", err)
 	}
+	return p
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+func TestCompileLayers(t *testing.T) {
+	t.Parallel()
+
+	// The product only exists in the override directories
+	productName := "products/layer_test_only"
+	org, team := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		filepath.Join(org, productName, "Widget.yaml"):  "name: Widget\ndescription: Org description.\nimmutable: true\n",
+		filepath.Join(team, productName, "Widget.yaml"): "description: Team description.\ncustom_code:\n  pre_create: '{{override_path}}/pre_create.go.tmpl'\n",
+		filepath.Join(team, productName, "Gadget.yaml"): "name: Gadget\n",
+	}
+	for filePath, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	overrideDirectories := []string{org, team}
+
+	if got, want := resourceFileNames(productName, overrideDirectories), []string{"Gadget.yaml", "Widget.yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected resource files %v, got %v", want, got)
+	}
+
+	layers := yamlLayers(filepath.Join(productName, "Widget.yaml"), overrideDirectories)
+	if got, want := layerNames(layers), org+", "+team; got != want {
+		t.Errorf("expected layers %q, got %q", want, got)
+	}
+
+	resource := &api.Resource{}
+	if err := compileLayers(layers, resource, overrideDirectories, google.NewDiagnostics()); err != nil {
+		t.Fatal(err)
+	}
+	if resource.Name != "Widget" || !resource.Immutable {
+		t.Errorf("expected fields from the first layer to be kept, got name %q and immutable %v", resource.Name, resource.Immutable)
+	}
+	if resource.Description != "Team description." {
+		t.Errorf("expected the last layer to win, got description %q", resource.Description)
+	}
+	if got, want := resource.CustomCode.PreCreate, team+"/pre_create.go.tmpl"; got != want {
+		t.Errorf("expected {{override_path}} to resolve to the layer of the file, got %q", got)
+	}
+}
+
+func TestLoadProduct_sensitiveApiFields(t *testing.T) {
+	defer func(v string) { *version = v }(*version)
+	*version = "ga"
+
+	diags := google.NewDiagnostics()
+	productApi := LoadProduct("products/secretmanager", nil, diags)
+	if productApi == nil || diags.HasErrors() {
+		t.Fatalf("unable to load product: %v", diags.All())
+	}
+
+	var secretVersion *api.Resource
+	for _, r := range productApi.Objects {
+		if r.Name == "SecretVersion" {
+			secretVersion = r
+		}
+	}
+	if secretVersion == nil {
+		t.Fatalf("expected a SecretVersion resource")
+	}
+
+	// secret_data and secret_data_wo are both sent as payload.data
+	if got, want := secretVersion.SensitiveApiFields(), []string{"payload.data"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected sensitive API fields %v, got %v", want, got)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
 	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api_description"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"github.com/getkin/kin-openapi/openapi3"
 	"gopkg.in/yaml.v2"
@@ -91,7 +92,7 @@ func (parser Parser) WriteYaml(filePath string) {
 		log.Fatalf("error reading header %v", err)
 	}
 
-	resourcePaths := findResources(doc)
+	resourcePaths := FindResources(doc)
 	productPath := buildProduct(filePath, parser.Output, doc, header)
 
 	// Disables line wrap for long strings
@@ -127,7 +128,7 @@ func (parser Parser) WriteYaml(filePath string) {
 	}
 }
 
-func findResources(doc *openapi3.T) [][]string {
+func FindResources(doc *openapi3.T) [][]string {
 	var resourcePaths [][]string
 
 	pathMap := doc.Paths.Map()
@@ -203,21 +204,14 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 }
 
 func baseUrl(resourcePath string) string {
-	base := strings.ReplaceAll(resourcePath, "{", "{{")
-	base = strings.ReplaceAll(base, "}", "}}")
-	// Some APIs use projectsId and locationsId, but we have standardized on these
-	base = strings.ReplaceAll(base, "projectsId", "project")
-	base = strings.ReplaceAll(base, "locationsId", "location")
-	base = stripVersion(base)
-	r := regexp.MustCompile(`\{\{(\w+)\}\}`)
-	matches := r.FindStringSubmatch(base)
-	for i := 0; i < len(matches); i++ {
-		match := matches[i]
-		base = strings.ReplaceAll(base, match, google.Underscore(match))
-	}
-	return base
+	base := stripVersion(resourcePath)
+	return pathParamRegex.ReplaceAllStringFunc(base, func(match string) string {
+		return fmt.Sprintf("{{%s}}", api_description.PathParamName(strings.Trim(match, "{}")))
+	})
 }
 
+var pathParamRegex = regexp.MustCompile(`\{(\w+)\}`)
+
 // OpenAPI paths are prefixed with the version of the API, which already exists
 // in the product. Strip it out here
 func stripVersion(path string) string {
@@ -226,14 +220,52 @@ func stripVersion(path string) string {
 	return re.ReplaceAllString(path, "")
 }
 
+// The operations of a single resource. Create is always set, the others are
+// nil when the API does not support them.
+type resourceOperations struct {
+	Create *openapi3.Operation
+	Get    *openapi3.Operation
+	Update *openapi3.Operation
+	Delete *openapi3.Operation
+
+	// PATCH or PUT
+	UpdateVerb string
+}
+
+// Finds the operations of resourceName. Google APIs name them
+// Create<Resource>, Get<Resource>, Update<Resource> and Delete<Resource>,
+// with everything but Create on the path of a single resource.
+func findOperations(resourcePath, resourceName string, root *openapi3.T) resourceOperations {
+	ops := resourceOperations{
+		Create: root.Paths.Find(resourcePath).Post,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(root.Paths.Map())) {
+		pathValue := root.Paths.Value(key)
+		if op := pathValue.Get; op != nil && op.OperationID == fmt.Sprintf("Get%s", resourceName) {
+			ops.Get = op
+		}
+		if op := pathValue.Delete; op != nil && op.OperationID == fmt.Sprintf("Delete%s", resourceName) {
+			ops.Delete = op
+		}
+		if op := pathValue.Patch; op != nil && op.OperationID == fmt.Sprintf("Update%s", resourceName) {
+			ops.Update = op
+			ops.UpdateVerb = "PATCH"
+		}
+		if op := pathValue.Put; op != nil && op.OperationID == fmt.Sprintf("Update%s", resourceName) && ops.Update == nil {
+			ops.Update = op
+			ops.UpdateVerb = "PUT"
+		}
+	}
+
+	return ops
+}
+
 func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T) api.Resource {
 	resource := api.Resource{}
 
-	parsedObjects := parseOpenApi(resourcePath, resourceName, root)
-
-	parameters := parsedObjects[0].([]*api.Type)
-	properties := parsedObjects[1].([]*api.Type)
-	queryParam := parsedObjects[2].(string)
+	ops := findOperations(resourcePath, resourceName, root)
+	parameters, properties, queryParam := parseOpenApi(resourceName, ops)
 
 	baseUrl := baseUrl(resourcePath)
 	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, google.Underscore(queryParam))
@@ -248,19 +280,39 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
 	resource.Description = "Description"
 
-	resource.AutogenAsync = true
-	async := api.NewAsync()
-	async.Operation.BaseUrl = "{{op_id}}"
-	async.Result.ResourceInsideResponse = true
-	resource.Async = async
+	var asyncActions []string
+	for _, action := range []struct {
+		name string
+		op   *openapi3.Operation
+	}{
+		{"create", ops.Create},
+		{"delete", ops.Delete},
+		{"update", ops.Update},
+	} {
+		if returnsOperation(action.op) {
+			asyncActions = append(asyncActions, action.name)
+		}
+	}
+	if len(asyncActions) > 0 {
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = asyncActions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = true
+		resource.Async = async
+	}
 
-	if hasUpdate(resourceName, root) {
-		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
+	if ops.Update != nil {
+		resource.UpdateVerb = ops.UpdateVerb
+		resource.UpdateMask = hasQueryParam(ops.Update, "updateMask")
 	} else {
 		resource.Immutable = true
 	}
 
+	if ops.Delete == nil {
+		resource.ExcludeDelete = true
+	}
+
 	example := r.Examples{}
 	example.Name = "name_of_example_file"
 	example.PrimaryResourceId = "example"
@@ -276,31 +328,70 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	return resource
 }
 
-func hasUpdate(resourceName string, root *openapi3.T) bool {
-	// Create and Update have different paths in the OpenAPI spec, so look
-	// through all paths to find one that matches the expected operation name
-	for _, pathValue := range root.Paths.Map() {
-		if pathValue.Patch == nil {
-			continue
-		}
-		if pathValue.Patch.OperationID == fmt.Sprintf("Update%s", resourceName) {
+func hasQueryParam(op *openapi3.Operation, name string) bool {
+	for _, param := range op.Parameters {
+		if param.Value != nil && param.Value.In == openapi3.ParameterInQuery && param.Value.Name == name {
 			return true
 		}
 	}
 	return false
 }
 
-func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
-	returnArray := []any{}
-	path := root.Paths.Find(resourcePath)
+// Returns true if op responds with a google.longrunning.Operation.
+func returnsOperation(op *openapi3.Operation) bool {
+	if op == nil || op.Responses == nil {
+		return false
+	}
+	response := op.Responses.Status(200)
+	if response == nil {
+		response = op.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return false
+	}
+	mediaType := response.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil {
+		return false
+	}
+
+	schema := mediaType.Schema
+	if strings.HasSuffix(schema.Ref, "/Operation") {
+		return true
+	}
+	// Inline or renamed operation schemas still have the standard fields
+	if schema.Value == nil {
+		return false
+	}
+	props := schema.Value.Properties
+	return props["name"] != nil && props["done"] != nil && (props["response"] != nil || props["metadata"] != nil)
+}
+
+// Returns the schema of the JSON request body of op, or nil if it has none.
+func requestBodySchema(op *openapi3.Operation) *openapi3.Schema {
+	if op == nil || op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	mediaType := op.RequestBody.Value.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+	return MergedSchema(mediaType.Schema)
+}
 
+// Returns the URL parameters and body properties of the resource, and the
+// name of the query parameter holding the id of a new resource.
+func parseOpenApi(resourceName string, ops resourceOperations) ([]*api.Type, []*api.Type, string) {
 	parameters := []*api.Type{}
 	var idParam string
-	for _, param := range path.Post.Parameters {
+	for _, param := range ops.Create.Parameters {
 		if strings.Contains(strings.ToLower(param.Value.Name), strings.ToLower(resourceName)) {
 			idParam = param.Value.Name
 		}
-		paramObj := writeObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
+		name := param.Value.Name
+		if param.Value.In == openapi3.ParameterInPath {
+			name = google.Camelize(api_description.PathParamName(name), "lower")
+		}
+		paramObj := writeObject(name, param.Value.Schema, true, make(map[*openapi3.Schema]bool))
 		description := param.Value.Description
 		if strings.TrimSpace(description) == "" {
 			description = "No description"
@@ -316,24 +407,116 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 		parameters = append(parameters, &paramObj)
 	}
 
-	properties := buildProperties(path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Properties, path.Post.RequestBody.Value.Content["application/json"].Schema.Value.Required)
+	createBody := requestBodySchema(ops.Create)
+	if createBody == nil {
+		return parameters, []*api.Type{}, idParam
+	}
+	properties := buildProperties(createBody.Properties, createBody.Required, make(map[*openapi3.Schema]bool))
+
+	// Fields that can be set on create but are missing from the update body
+	// can only be changed by recreating the resource.
+	if updateBody := requestBodySchema(ops.Update); updateBody != nil {
+		markImmutable(properties, updateBody)
+	}
 
-	returnArray = append(returnArray, parameters)
-	returnArray = append(returnArray, properties)
-	returnArray = append(returnArray, idParam)
+	return parameters, properties, idParam
+}
+
+// Marks every settable property that does not appear in the update request
+// body as immutable.
+func markImmutable(properties []*api.Type, updateBody *openapi3.Schema) {
+	for _, p := range properties {
+		if p.Output {
+			continue
+		}
+		updateProp, ok := updateBody.Properties[p.Name]
+		if !ok {
+			p.Immutable = true
+			continue
+		}
+		if p.IsA("NestedObject") {
+			markImmutable(p.Properties, MergedSchema(updateProp))
+		}
+	}
+}
 
-	return returnArray
+// Returns the JSON type of a schema, inferring it from the rest of the
+// schema when `type` is not set.
+func SchemaType(s *openapi3.Schema) string {
+	if s.Type != nil {
+		for _, t := range *s.Type {
+			if t != openapi3.TypeNull {
+				return t
+			}
+		}
+	}
+	switch {
+	case len(s.Properties) > 0 || s.AdditionalProperties.Schema != nil:
+		return openapi3.TypeObject
+	case s.Items != nil:
+		return openapi3.TypeArray
+	default:
+		// Schemas without a type accept any value, eg: google.protobuf.Value
+		return openapi3.TypeString
+	}
 }
 
-func propType(prop *openapi3.SchemaRef) openapi3.Types {
-	if len(prop.Value.AllOf) > 0 {
-		return *prop.Value.AllOf[0].Value.Type
-	} else {
-		return *prop.Value.Type
+// Flattens allOf, oneOf and anyOf into a single schema. Properties of the
+// oneOf and anyOf alternatives are all added as optional properties.
+func MergedSchema(ref *openapi3.SchemaRef) *openapi3.Schema {
+	s := ref.Value
+	if len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 {
+		return s
+	}
+
+	merged := *s
+	merged.AllOf, merged.OneOf, merged.AnyOf = nil, nil, nil
+	merged.Properties = maps.Clone(s.Properties)
+	if merged.Properties == nil {
+		merged.Properties = make(openapi3.Schemas)
+	}
+	merged.Required = slices.Clone(s.Required)
+
+	mergeInto := func(sub *openapi3.Schema, required bool) {
+		if merged.Type == nil {
+			merged.Type = sub.Type
+		}
+		if merged.Description == "" {
+			merged.Description = sub.Description
+		}
+		if merged.Items == nil {
+			merged.Items = sub.Items
+		}
+		if merged.AdditionalProperties.Schema == nil {
+			merged.AdditionalProperties = sub.AdditionalProperties
+		}
+		if len(merged.Enum) == 0 {
+			merged.Enum = sub.Enum
+		}
+		merged.ReadOnly = merged.ReadOnly || sub.ReadOnly
+		for k, v := range sub.Properties {
+			if _, ok := merged.Properties[k]; !ok {
+				merged.Properties[k] = v
+			}
+		}
+		if required {
+			merged.Required = append(merged.Required, sub.Required...)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		mergeInto(MergedSchema(sub), true)
+	}
+	for _, sub := range append(slices.Clone(s.OneOf), s.AnyOf...) {
+		mergeInto(MergedSchema(sub), false)
 	}
+	return &merged
 }
 
-func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, urlParam bool) api.Type {
+// Builds the MMv1 type for a schema. seen holds the schemas being built by
+// the callers, so that recursive schemas (eg: a Struct holding Values that
+// hold Structs) are written as a JSON string instead of recursing forever.
+func writeObject(name string, obj *openapi3.SchemaRef, urlParam bool, seen map[*openapi3.Schema]bool) api.Type {
 	var field api.Type
 
 	switch name {
@@ -346,18 +529,24 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	}
 	additionalDescription := ""
 
-	if len(obj.Value.AllOf) > 0 {
-		obj = obj.Value.AllOf[0]
-		objType = *obj.Value.Type
+	if seen[obj.Value] {
+		log.Printf("%s is recursive, writing it as a JSON string", name)
+		field.Name = name
+		api_description.WriteJsonString(&field, trimDescription(obj.Value.Description))
+		return field
 	}
+	seen[obj.Value] = true
+	defer delete(seen, obj.Value)
+
+	value := MergedSchema(obj)
 
 	field.Name = name
-	switch objType[0] {
+	switch SchemaType(value) {
 	case "string":
 		field.Type = "String"
-		if len(obj.Value.Enum) > 0 {
+		if len(value.Enum) > 0 {
 			var enums []string
-			for _, enum := range obj.Value.Enum {
+			for _, enum := range value.Enum {
 				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
 					continue
 				}
@@ -378,38 +567,37 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 			break
 		}
 
-		if obj.Value.AdditionalProperties.Schema != nil && obj.Value.AdditionalProperties.Schema.Value.Type.Is("string") {
+		if value.AdditionalProperties.Schema != nil && value.AdditionalProperties.Schema.Value.Type.Is("string") {
 			// AdditionalProperties with type string is a string -> string map
 			field.Type = "KeyValuePairs"
 			break
 		}
 
+		if len(value.Properties) == 0 {
+			// Free-form objects such as google.protobuf.Struct
+			api_description.WriteJsonString(&field, trimDescription(value.Description))
+			return field
+		}
+
 		field.Type = "NestedObject"
 
-		field.Properties = buildProperties(obj.Value.Properties, obj.Value.Required)
+		field.Properties = buildProperties(value.Properties, value.Required, seen)
 	case "array":
 		field.Type = "Array"
-		var subField api.Type
-		typ := *obj.Value.Items.Value.Type
-		switch typ[0] {
-		case "string":
-			subField.Type = "String"
-		case "integer":
-			subField.Type = "Integer"
-		case "number":
-			subField.Type = "Double"
-		case "boolean":
-			subField.Type = "Boolean"
-		case "object":
-			subField.Type = "NestedObject"
-			subField.Properties = buildProperties(obj.Value.Items.Value.Properties, obj.Value.Items.Value.Required)
+		item := writeObject(name, value.Items, false, seen)
+		subField := api.Type{
+			Type:          item.Type,
+			Properties:    item.Properties,
+			ItemType:      item.ItemType,
+			CustomExpand:  item.CustomExpand,
+			CustomFlatten: item.CustomFlatten,
 		}
 		field.ItemType = &subField
 	default:
-		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
+		log.Fatalf("Failed to identify field type for %s %s", field.Name, SchemaType(value))
 	}
 
-	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
+	description := fmt.Sprintf("%s %s", value.Description, additionalDescription)
 	if strings.TrimSpace(description) == "" {
 		description = "No description"
 	}
@@ -422,7 +610,7 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	}
 
 	// These methods are only available when the field is set
-	if obj.Value.ReadOnly {
+	if value.ReadOnly {
 		field.Output = true
 	}
 
@@ -441,11 +629,11 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	return field
 }
 
-func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
+func buildProperties(props openapi3.Schemas, required []string, seen map[*openapi3.Schema]bool) []*api.Type {
 	properties := []*api.Type{}
 	for _, k := range slices.Sorted(maps.Keys(props)) {
 		prop := props[k]
-		propObj := writeObject(k, prop, propType(prop), false)
+		propObj := writeObject(k, prop, false, seen)
 		if slices.Contains(required, k) {
 			propObj.Required = true
 		}
@@ -0,0 +1,196 @@
+package openapi_generate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: Widget API
+  version: v1
+servers:
+  - url: https://widget.googleapis.com
+paths:
+  /v1/projects/{projectsId}/locations/{locationsId}/widgets:
+    post:
+      operationId: CreateWidget
+      parameters:
+        - {name: projectsId, in: path, required: true, schema: {type: string}}
+        - {name: locationsId, in: path, required: true, schema: {type: string}}
+        - {name: widgetId, in: query, schema: {type: string}, description: Id of the widget.}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Widget'}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Operation'}
+  /v1/projects/{projectsId}/locations/{locationsId}/widgets/{widgetsId}:
+    get:
+      operationId: GetWidget
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Widget'}
+    patch:
+      operationId: UpdateWidget
+      parameters:
+        - {name: updateMask, in: query, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/WidgetUpdate'}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Widget'}
+components:
+  schemas:
+    Operation:
+      type: object
+      properties:
+        name: {type: string}
+        done: {type: boolean}
+        response: {type: object, additionalProperties: true}
+    Widget:
+      type: object
+      properties:
+        displayName: {type: string, description: Name shown in the console.}
+        zone: {type: string}
+        settings:
+          allOf:
+            - {$ref: '#/components/schemas/Settings'}
+          description: Widget settings.
+        tree: {$ref: '#/components/schemas/Node'}
+        source:
+          oneOf:
+            - {type: object, properties: {bucket: {type: string}}}
+            - {type: object, properties: {repository: {type: string}}}
+    WidgetUpdate:
+      type: object
+      properties:
+        displayName: {type: string}
+        settings:
+          type: object
+          properties:
+            color: {type: string}
+        tree: {$ref: '#/components/schemas/Node'}
+        source:
+          type: object
+          properties:
+            bucket: {type: string}
+            repository: {type: string}
+    Settings:
+      type: object
+      properties:
+        color: {type: string}
+        size: {type: integer}
+    Node:
+      type: object
+      properties:
+        value: {type: string}
+        children:
+          type: array
+          items: {$ref: '#/components/schemas/Node'}
+`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	loader := &openapi3.Loader{Context: context.Background()}
+	doc, err := loader.LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("cannot load spec: %v", err)
+	}
+	return doc
+}
+
+func findProperty(props []*api.Type, name string) *api.Type {
+	for _, p := range props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func TestBuildResource(t *testing.T) {
+	t.Parallel()
+
+	doc := loadTestSpec(t)
+	resource := buildResource("widget_v1.yaml", "/v1/projects/{projectsId}/locations/{locationsId}/widgets", "Widget", doc)
+
+	if got, want := resource.BaseUrl, "projects/{{project}}/locations/{{location}}/widgets"; got != want {
+		t.Errorf("expected base_url %q, got %q", want, got)
+	}
+	if resource.UpdateVerb != "PATCH" || !resource.UpdateMask {
+		t.Errorf("expected a PATCH update with an update mask, got %q and %v", resource.UpdateVerb, resource.UpdateMask)
+	}
+	if resource.Immutable {
+		t.Errorf("expected an updatable resource")
+	}
+	if !resource.ExcludeDelete {
+		t.Errorf("expected delete to be excluded when there is no Delete operation")
+	}
+	if resource.Async == nil || len(resource.Async.Actions) != 1 || resource.Async.Actions[0] != "create" {
+		t.Errorf("expected an async block for create only, got %+v", resource.Async)
+	}
+
+	if p := findProperty(resource.Parameters, "location"); p == nil || !p.Immutable || !p.UrlParamOnly {
+		t.Errorf("expected an immutable location url parameter, got %+v", p)
+	}
+	if p := findProperty(resource.Parameters, "project"); p != nil {
+		t.Errorf("expected project to be inferred from the url")
+	}
+
+	cases := []struct {
+		description string
+		path        []string
+		immutable   bool
+		fieldType   string
+	}{
+		{"field in the update body is mutable", []string{"displayName"}, false, "String"},
+		{"field missing from the update body is immutable", []string{"zone"}, true, "String"},
+		{"allOf is merged into a nested object", []string{"settings", "color"}, false, "String"},
+		{"nested field missing from the update body is immutable", []string{"settings", "size"}, true, "Integer"},
+		{"oneOf alternatives become properties", []string{"source", "repository"}, false, "String"},
+		{"recursive schemas end in a JSON string", []string{"tree", "children"}, false, "Array"},
+	}
+	for _, tc := range cases {
+		props := resource.Properties
+		var p *api.Type
+		for _, name := range tc.path {
+			p = findProperty(props, name)
+			if p == nil {
+				break
+			}
+			props = p.Properties
+		}
+		if p == nil {
+			t.Errorf("%s: property %v not found", tc.description, tc.path)
+			continue
+		}
+		if p.Immutable != tc.immutable {
+			t.Errorf("%s: expected immutable %v, got %v", tc.description, tc.immutable, p.Immutable)
+		}
+		if p.Type != tc.fieldType {
+			t.Errorf("%s: expected type %s, got %s", tc.description, tc.fieldType, p.Type)
+		}
+	}
+
+	children := findProperty(findProperty(resource.Properties, "tree").Properties, "children")
+	if children.ItemType == nil || children.ItemType.Type != "String" || children.ItemType.CustomExpand == "" {
+		t.Errorf("expected the recursive item type to be a JSON string, got %+v", children.ItemType)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+const (
+	FileAdded   = "added"
+	FileChanged = "changed"
+	FileDeleted = "deleted"
+)
+
+// Keeps generated files in memory instead of writing them to the output
+// folder, so that they can be compared with the files already there.
+type DryRun struct {
+	mu sync.Mutex
+
+	// Generated content keyed by file path
+	files map[string][]byte
+
+	// Files that a previous run generated but this run no longer does
+	deleted map[string]bool
+}
+
+// A generated file that differs from the output folder.
+type FileChange struct {
+	// Path relative to the output folder
+	Path string `json:"path"`
+
+	// One of added, changed or deleted
+	Status string `json:"status"`
+}
+
+var dryRun *DryRun
+
+// Keeps every file generated for the rest of the run in memory.
+func EnableDryRun() *DryRun {
+	dryRun = &DryRun{
+		files:   make(map[string][]byte),
+		deleted: make(map[string]bool),
+	}
+	return dryRun
+}
+
+// Writes a generated file, or keeps it in memory during a dry run.
+func writeOutputFile(filePath string, content []byte, perm fs.FileMode) error {
+	if dryRun == nil {
+		return os.WriteFile(filePath, content, perm)
+	}
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	dryRun.files[filePath] = bytes.Clone(content)
+	delete(dryRun.deleted, filePath)
+	return nil
+}
+
+// Reads a file written by writeOutputFile.
+func readOutputFile(filePath string) ([]byte, error) {
+	if dryRun == nil {
+		return os.ReadFile(filePath)
+	}
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	content, ok := dryRun.files[filePath]
+	if !ok {
+		return nil, fmt.Errorf("%s was not generated: %w", filePath, os.ErrNotExist)
+	}
+	return bytes.Clone(content), nil
+}
+
+// Returns true if filePath was written by writeOutputFile. Outside of a dry
+// run this is any existing file.
+func outputFileExists(filePath string) bool {
+	if dryRun == nil {
+		_, err := os.Stat(filePath)
+		return !errors.Is(err, os.ErrNotExist)
+	}
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	_, ok := dryRun.files[filePath]
+	return ok
+}
+
+// Creates a directory in the output folder. Nothing is created during a dry
+// run.
+func makeOutputDir(dir string, perm fs.FileMode) error {
+	if dryRun == nil {
+		return os.MkdirAll(dir, perm)
+	}
+	return nil
+}
+
+// Records that a file generated by a previous run is no longer generated.
+func deleteOutputFile(filePath string) {
+	if dryRun == nil {
+		return
+	}
+
+	dryRun.mu.Lock()
+	defer dryRun.mu.Unlock()
+	if _, ok := dryRun.files[filePath]; !ok {
+		dryRun.deleted[filePath] = true
+	}
+}
+
+// Runs goimports over the Go files kept in memory, resolving imports as if
+// each file was already in the output folder.
+func (d *DryRun) fixImports() {
+	if _, err := exec.LookPath("goimports"); err != nil {
+		log.Printf("goimports not found, imports are shown as rendered")
+		return
+	}
+
+	var paths []string
+	goimportFiles.Range(func(filePath, _ any) bool {
+		paths = append(paths, filePath.(string))
+		return true
+	})
+
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for filePath := range work {
+				content, err := readOutputFile(filePath)
+				if err != nil {
+					continue
+				}
+				cmd := exec.Command("goimports", "-srcdir", filepath.Dir(filePath))
+				cmd.Stdin = bytes.NewReader(content)
+				var stdout, stderr bytes.Buffer
+				cmd.Stdout = &stdout
+				cmd.Stderr = &stderr
+				if err := cmd.Run(); err != nil {
+					log.Fatalf("goimports failed for %s: %v\n%s", filePath, err, stderr.String())
+				}
+				writeOutputFile(filePath, stdout.Bytes(), 0644)
+			}
+		}()
+	}
+	for _, p := range paths {
+		work <- p
+	}
+	close(work)
+	wg.Wait()
+}
+
+// Compares the generated files with the files in outputFolder. Files that
+// are identical are left out.
+func (d *DryRun) Changes(outputFolder string) ([]FileChange, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var changes []FileChange
+	for filePath, content := range d.files {
+		rel, err := filepath.Rel(outputFolder, filePath)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := os.ReadFile(filePath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			changes = append(changes, FileChange{Path: rel, Status: FileAdded})
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(existing, content):
+			changes = append(changes, FileChange{Path: rel, Status: FileChanged})
+		}
+	}
+	for filePath := range d.deleted {
+		if _, err := os.Stat(filePath); err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(outputFolder, filePath)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, FileChange{Path: rel, Status: FileDeleted})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Writes a unified diff of every changed file to w, with paths relative to
+// outputFolder.
+func (d *DryRun) WriteDiff(w io.Writer, outputFolder string) error {
+	changes, err := d.Changes(outputFolder)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		filePath := filepath.Join(outputFolder, change.Path)
+		oldName, newName := "a/"+change.Path, "b/"+change.Path
+
+		var oldContent, newContent []byte
+		if change.Status != FileAdded {
+			if oldContent, err = os.ReadFile(filePath); err != nil {
+				return err
+			}
+		} else {
+			oldName = "/dev/null"
+		}
+		if change.Status != FileDeleted {
+			d.mu.Lock()
+			newContent = d.files[filePath]
+			d.mu.Unlock()
+		} else {
+			newName = "/dev/null"
+		}
+
+		if _, err := io.WriteString(w, google.UnifiedDiff(oldName, newName, string(oldContent), string(newContent))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes the list of changed files to w as JSON.
+func (d *DryRun) WriteChanges(w io.Writer, outputFolder string) error {
+	changes, err := d.Changes(outputFolder)
+	if err != nil {
+		return err
+	}
+	if changes == nil {
+		changes = []FileChange{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(changes)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDryRunChanges(t *testing.T) {
+	t.Parallel()
+
+	outputFolder := t.TempDir()
+	for name, content := range map[string]string{
+		"unchanged.go": "package a\n",
+		"changed.go":   "package a\n",
+		"deleted.go":   "package a\n",
+	} {
+		if err := os.WriteFile(filepath.Join(outputFolder, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := &DryRun{
+		files: map[string][]byte{
+			filepath.Join(outputFolder, "unchanged.go"): []byte("package a\n"),
+			filepath.Join(outputFolder, "changed.go"):   []byte("package b\n"),
+			filepath.Join(outputFolder, "added.go"):     []byte("package a\n"),
+		},
+		deleted: map[string]bool{
+			filepath.Join(outputFolder, "deleted.go"): true,
+			// Already gone from the output folder
+			filepath.Join(outputFolder, "missing.go"): true,
+		},
+	}
+
+	got, err := d.Changes(outputFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []FileChange{
+		{Path: "added.go", Status: FileAdded},
+		{Path: "changed.go", Status: FileChanged},
+		{Path: "deleted.go", Status: FileDeleted},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+}
+
+// Not parallel since it uses the dry run and cache of the package.
+func TestDryRunManifestDeletions(t *testing.T) {
+	outputFolder := t.TempDir()
+	if EnableGenerationManifest(outputFolder) {
+		t.Fatalf("expected no manifest without a cache file")
+	}
+
+	kept := filepath.Join(outputFolder, "resource_test_widget.go")
+	removed := filepath.Join(outputFolder, "resource_test_widget_sweeper.go")
+	for _, f := range []string{kept, removed} {
+		if err := os.WriteFile(f, []byte("package test\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	previous := &GenerationCache{outputFolder: outputFolder, Entries: make(map[string]GenerationCacheEntry)}
+	previous.Store("Terraform/ga/Test/Widget", "abc", []string{kept, removed})
+	if err := previous.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := EnableDryRun()
+	t.Cleanup(func() {
+		dryRun = nil
+		generationCache = nil
+	})
+	if !EnableGenerationManifest(outputFolder) {
+		t.Fatalf("expected the cache file to be loaded as a manifest")
+	}
+	if generationCache.Fresh("Terraform/ga/Test/Widget", "abc") {
+		t.Errorf("expected every resource to be regenerated during a dry run")
+	}
+
+	if err := writeOutputFile(kept, []byte("package test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	generationCache.Store("Terraform/ga/Test/Widget", "def", []string{kept})
+
+	got, err := d.Changes(outputFolder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []FileChange{
+		{Path: "resource_test_widget_sweeper.go", Status: FileDeleted},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+	if err := SaveGenerationCache(); err != nil {
+		t.Fatal(err)
+	}
+	if loaded := LoadGenerationCache(outputFolder); loaded.Entries["Terraform/ga/Test/Widget"].Hash != "abc" {
+		t.Errorf("expected the cache file to be left as is by a dry run")
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// Name of the cache file, relative to the output folder.
+const GenerationCacheFile = ".mmv1_generation_cache.json"
+
+// Templates that every resource is rendered with. Files referenced from
+// resource YAML (custom_code, custom_expand, examples, ...) are added per
+// resource.
+var sharedTemplateGlobs = []string{
+	"templates/terraform/*.tmpl",
+	"templates/terraform/custom_flatten/bigquery_table_ref.go.tmpl",
+	"templates/terraform/examples/base_configs/*.tmpl",
+	"templates/terraform/iam/*.tmpl",
+	"templates/terraform/iam/example_config_body/*.tmpl",
+}
+
+// Template paths hardcoded inside other templates, eg:
+// {{ $.CustomTemplate "templates/terraform/update_mask.go.tmpl" false }}
+var templateReferenceRegex = regexp.MustCompile(`"((?:templates|third_party)/[^"\s]+)"`)
+
+// Fields that point back up the object tree. They are covered by the hash of
+// the owning resource or product.
+var skippedHashFields = map[string]bool{
+	"ProductMetadata":  true,
+	"ResourceMetadata": true,
+	"ParentMetadata":   true,
+}
+
+// A content-addressed record of the resources generated into an output
+// folder. A resource is regenerated only when the hash of its inputs (its
+// YAML after overrides are merged, the templates and custom code it uses,
+// and the generator binary) differs from the previous run or one of the
+// files it produced is missing.
+type GenerationCache struct {
+	outputFolder string
+
+	// Only used to report the files of the previous run that are no longer
+	// generated: every resource is regenerated and the cache isn't saved.
+	manifestOnly bool
+
+	mu sync.Mutex
+
+	Entries map[string]GenerationCacheEntry `json:"entries"`
+}
+
+type GenerationCacheEntry struct {
+	Hash string `json:"hash"`
+
+	// Generated files, relative to the output folder
+	Files []string `json:"files"`
+}
+
+var generationCache *GenerationCache
+
+var generatorDigest struct {
+	once  sync.Once
+	value string
+	err   error
+}
+
+// Loads the cache stored in outputFolder. A missing or unreadable cache file
+// starts an empty cache.
+func LoadGenerationCache(outputFolder string) *GenerationCache {
+	c := &GenerationCache{
+		outputFolder: outputFolder,
+		Entries:      make(map[string]GenerationCacheEntry),
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputFolder, GenerationCacheFile))
+	if err == nil {
+		if err := json.Unmarshal(content, c); err != nil || c.Entries == nil {
+			c.Entries = make(map[string]GenerationCacheEntry)
+		}
+	}
+	return c
+}
+
+// Loads the cache stored in outputFolder and uses it for the rest of the run.
+func EnableGenerationCache(outputFolder string) {
+	generationCache = LoadGenerationCache(outputFolder)
+}
+
+// Loads the cache stored in outputFolder as the list of files generated by
+// the previous run, so that a dry run reports the files that are no longer
+// generated. Returns false if outputFolder has no cache file.
+func EnableGenerationManifest(outputFolder string) bool {
+	if _, err := os.Stat(filepath.Join(outputFolder, GenerationCacheFile)); err != nil {
+		return false
+	}
+	generationCache = LoadGenerationCache(outputFolder)
+	generationCache.manifestOnly = true
+	return true
+}
+
+// Writes the cache back to the output folder. Should only be called once
+// every generated file has been written and formatted.
+func SaveGenerationCache() error {
+	if generationCache == nil || generationCache.manifestOnly {
+		return nil
+	}
+	return generationCache.Save()
+}
+
+func (c *GenerationCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.outputFolder, GenerationCacheFile), append(content, '\n'), 0644)
+}
+
+// Returns true if key was generated from inputs with the same hash and all
+// of its files are still present. A nil or manifest only cache never matches.
+func (c *GenerationCache) Fresh(key, hash string) bool {
+	if c == nil || c.manifestOnly {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.Entries[key]
+	c.mu.Unlock()
+
+	if !ok || entry.Hash != hash {
+		return false
+	}
+	for _, f := range entry.Files {
+		if _, err := os.Stat(filepath.Join(c.outputFolder, f)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Records the files generated for key. A nil cache ignores the call.
+func (c *GenerationCache) Store(key, hash string, files []string) {
+	if c == nil {
+		return
+	}
+
+	relativeFiles := make([]string, 0, len(files))
+	for _, f := range files {
+		if rel, err := filepath.Rel(c.outputFolder, f); err == nil {
+			f = rel
+		}
+		relativeFiles = append(relativeFiles, f)
+	}
+	sort.Strings(relativeFiles)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range c.Entries[key].Files {
+		if !slices.Contains(relativeFiles, f) {
+			deleteOutputFile(filepath.Join(c.outputFolder, f))
+		}
+	}
+	c.Entries[key] = GenerationCacheEntry{Hash: hash, Files: relativeFiles}
+}
+
+// Forgets every entry under prefix whose key is not in keep, eg: resources
+// that were removed from a product. Their files are reported as deleted
+// during a dry run.
+func (c *GenerationCache) Prune(prefix string, keep []string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.Entries {
+		if !strings.HasPrefix(key, prefix) || slices.Contains(keep, key) {
+			continue
+		}
+		for _, f := range entry.Files {
+			deleteOutputFile(filepath.Join(c.outputFolder, f))
+		}
+		delete(c.Entries, key)
+	}
+}
+
+// Hashes everything that affects the files generated for object: the
+// generator binary, the generation options, the product and resource
+// definitions and the contents of every template they use.
+func ResourceInputHash(object api.Resource, product *api.Product, options ...string) (string, error) {
+	h := sha256.New()
+
+	digest, err := generatorVersion()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "generator %s\n", digest)
+	for _, o := range options {
+		fmt.Fprintf(h, "option %s\n", o)
+	}
+
+	files := make(map[string]bool)
+
+	// Resources can read the base url of other resources in the product
+	// through ResourceRef properties, so those are part of the product.
+	siblings := make([]string, 0, len(product.Objects))
+	for _, r := range product.Objects {
+		siblings = append(siblings, fmt.Sprintf("%s %s", r.Name, r.BaseUrl))
+	}
+	productCopy := *product
+	productCopy.Objects = nil
+	fmt.Fprintf(h, "product\n")
+	hashValue(h, reflect.ValueOf(productCopy), files)
+	fmt.Fprintf(h, "siblings %q\n", siblings)
+
+	fmt.Fprintf(h, "resource\n")
+	hashValue(h, reflect.ValueOf(object), files)
+	if object.StateUpgraders {
+		files[object.StateMigrationFile()] = true
+	}
+
+	for _, pattern := range sharedTemplateGlobs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, m := range matches {
+			files[m] = true
+		}
+	}
+
+	if err := hashFiles(h, files); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Writes the contents of files, and of any template they reference, to h
+// in a stable order.
+func hashFiles(h hash.Hash, files map[string]bool) error {
+	pending := make([]string, 0, len(files))
+	for f := range files {
+		pending = append(pending, f)
+	}
+
+	contents := make(map[string][]byte)
+	for len(pending) > 0 {
+		f := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := contents[f]; ok {
+			continue
+		}
+
+		content, err := os.ReadFile(f)
+		if errors.Is(err, os.ErrNotExist) {
+			// Optional files such as state migrations are only read
+			// when they exist
+			contents[f] = nil
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		contents[f] = content
+
+		for _, match := range templateReferenceRegex.FindAllSubmatch(content, -1) {
+			pending = append(pending, string(match[1]))
+		}
+	}
+
+	names := make([]string, 0, len(contents))
+	for f := range contents {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	for _, f := range names {
+		sum := sha256.Sum256(contents[f])
+		fmt.Fprintf(h, "file %s %x\n", f, sum)
+	}
+	return nil
+}
+
+// Writes a stable representation of v to h. String values that name an
+// existing template or Go file are added to files.
+func hashValue(h io.Writer, v reflect.Value, files map[string]bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprint(h, "nil;")
+			return
+		}
+		hashValue(h, v.Elem(), files)
+	case reflect.Struct:
+		t := v.Type()
+		fmt.Fprintf(h, "%s{", t.Name())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("yaml") == "-" || skippedHashFields[field.Name] {
+				continue
+			}
+			fmt.Fprintf(h, "%s:", field.Name)
+			hashValue(h, v.Field(i), files)
+		}
+		fmt.Fprint(h, "}")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprint(h, "[")
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), files)
+		}
+		fmt.Fprint(h, "]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		fmt.Fprint(h, "map[")
+		for _, k := range keys {
+			hashValue(h, k, files)
+			fmt.Fprint(h, "=")
+			hashValue(h, v.MapIndex(k), files)
+		}
+		fmt.Fprint(h, "]")
+	case reflect.String:
+		s := v.String()
+		fmt.Fprintf(h, "%q;", s)
+		if strings.HasSuffix(s, ".tmpl") || strings.HasSuffix(s, ".go") {
+			if info, err := os.Stat(s); err == nil && !info.IsDir() {
+				files[s] = true
+			}
+		}
+	default:
+		fmt.Fprintf(h, "%v;", v.Interface())
+	}
+}
+
+// Returns a digest of the running generator binary, so that changes to the
+// generator itself invalidate the cache.
+func generatorVersion() (string, error) {
+	generatorDigest.once.Do(func() {
+		executable, err := os.Executable()
+		if err != nil {
+			generatorDigest.err = err
+			return
+		}
+		f, err := os.Open(executable)
+		if err != nil {
+			generatorDigest.err = err
+			return
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			generatorDigest.err = err
+			return
+		}
+		generatorDigest.value = hex.EncodeToString(h.Sum(nil))
+	})
+	return generatorDigest.value, generatorDigest.err
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestGenerationCacheFresh(t *testing.T) {
+	t.Parallel()
+
+	outputFolder := t.TempDir()
+	generated := filepath.Join(outputFolder, "google", "resource_test_widget.go")
+	if err := os.MkdirAll(filepath.Dir(generated), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(generated, []byte("package test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &GenerationCache{outputFolder: outputFolder, Entries: make(map[string]GenerationCacheEntry)}
+	c.Store("Terraform/ga/Test/Widget", "abc", []string{generated})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := LoadGenerationCache(outputFolder)
+
+	if !loaded.Fresh("Terraform/ga/Test/Widget", "abc") {
+		t.Errorf("expected an unchanged resource to be fresh")
+	}
+	if loaded.Fresh("Terraform/ga/Test/Widget", "def") {
+		t.Errorf("expected a resource with a different hash to be stale")
+	}
+	if loaded.Fresh("Terraform/beta/Test/Widget", "abc") {
+		t.Errorf("expected an unknown resource to be stale")
+	}
+
+	if err := os.Remove(generated); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Fresh("Terraform/ga/Test/Widget", "abc") {
+		t.Errorf("expected a resource with a missing file to be stale")
+	}
+
+	var disabled *GenerationCache
+	if disabled.Fresh("Terraform/ga/Test/Widget", "abc") {
+		t.Errorf("expected a nil cache to never be fresh")
+	}
+}
+
+func TestResourceInputHash(t *testing.T) {
+	t.Parallel()
+
+	product := &api.Product{Name: "Test"}
+	base := func() api.Resource {
+		return api.Resource{
+			Name:    "Widget",
+			BaseUrl: "projects/{{project}}/widgets",
+			Properties: []*api.Type{
+				{Name: "foo", Type: "String"},
+			},
+		}
+	}
+
+	hash := func(r api.Resource, options ...string) string {
+		h, err := ResourceInputHash(r, product, options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	original := hash(base())
+	if got := hash(base()); got != original {
+		t.Errorf("expected identical resources to have the same hash")
+	}
+
+	changedProperty := base()
+	changedProperty.Properties = []*api.Type{{Name: "foo", Type: "Integer"}}
+	if hash(changedProperty) == original {
+		t.Errorf("expected a changed property to change the hash")
+	}
+
+	if hash(base(), "docs=false") == original {
+		t.Errorf("expected different options to change the hash")
+	}
+}
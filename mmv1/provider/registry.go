@@ -0,0 +1,224 @@
+// Copyright 2026 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+)
+
+// Name of the provider used when --provider is not set.
+const DefaultProvider = "terraform"
+
+// Builds a provider for a product at a version. It is called once for every
+// product, and once more with the first product loaded to copy and compile
+// the common files.
+type Factory func(product *api.Product, versionName string, startTime time.Time) Provider
+
+// A provider that can be selected with --provider.
+type Registration struct {
+	// Value of --provider that selects the provider.
+	Name string
+
+	Description string
+
+	New Factory
+
+	// Adds flags specific to the provider. Optional. Flags are registered for
+	// every provider, so they should be prefixed with the provider name.
+	Flags func(fs *flag.FlagSet)
+}
+
+// A set of providers, keyed by name.
+type Registry struct {
+	providers map[string]Registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Registration)}
+}
+
+// Adds a provider to the registry.
+func (r *Registry) Register(registration Registration) error {
+	if registration.Name == "" {
+		return fmt.Errorf("missing `Name` for provider")
+	}
+	if registration.New == nil {
+		return fmt.Errorf("provider %s has no `New` function", registration.Name)
+	}
+	if _, ok := r.providers[registration.Name]; ok {
+		return fmt.Errorf("provider %s is registered more than once", registration.Name)
+	}
+	r.providers[registration.Name] = registration
+	return nil
+}
+
+// Returns every registered provider, sorted by name.
+func (r *Registry) Providers() []Registration {
+	registrations := make([]Registration, 0, len(r.providers))
+	for _, registration := range r.providers {
+		registrations = append(registrations, registration)
+	}
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].Name < registrations[j].Name
+	})
+	return registrations
+}
+
+// Returns the provider registered as name.
+func (r *Registry) Lookup(name string) (Registration, bool) {
+	registration, ok := r.providers[name]
+	return registration, ok
+}
+
+// Builds the provider registered as name, or the default provider if name is
+// empty.
+func (r *Registry) New(name string, product *api.Product, versionName string, startTime time.Time) (Provider, error) {
+	if name == "" {
+		name = DefaultProvider
+	}
+	registration, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+	return registration.New(product, versionName, startTime), nil
+}
+
+// Adds the flags of every registered provider to fs.
+func (r *Registry) RegisterFlags(fs *flag.FlagSet) {
+	for _, registration := range r.Providers() {
+		if registration.Flags != nil {
+			registration.Flags(fs)
+		}
+	}
+}
+
+// The registry of the mmv1 binary, used by the functions below.
+var defaultRegistry = NewRegistry()
+
+// Adds a provider to the default registry. Providers are expected to register
+// themselves from an init function; providers outside of this package are
+// enabled by importing their package from main.
+func Register(registration Registration) {
+	if err := defaultRegistry.Register(registration); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Returns every provider of the default registry, sorted by name.
+func Providers() []Registration {
+	return defaultRegistry.Providers()
+}
+
+// Returns the provider of the default registry registered as name.
+func Lookup(name string) (Registration, bool) {
+	return defaultRegistry.Lookup(name)
+}
+
+// Builds the provider of the default registry registered as name, or the
+// default provider if name is empty.
+func New(name string, product *api.Product, versionName string, startTime time.Time) (Provider, error) {
+	return defaultRegistry.New(name, product, versionName, startTime)
+}
+
+// Adds the flags of every provider of the default registry to fs.
+func RegisterFlags(fs *flag.FlagSet) {
+	defaultRegistry.RegisterFlags(fs)
+}
+
+// Generates the files of a single resource. Providers implementing it can
+// call GenerateObjects from Generate instead of looping over resources
+// themselves.
+type ResourceGenerator interface {
+	// Returns the paths of the files written.
+	GenerateObject(object api.Resource, outputFolder, versionName string, generateCode, generateDocs bool) []string
+}
+
+// Generates the files shared by every resource of a product. Called by
+// GenerateObjects after every resource has been generated.
+type ProductGenerator interface {
+	GenerateProductFiles(outputFolder string, generateCode, generateDocs bool)
+}
+
+// Implemented by providers whose output is tracked by the generation cache.
+// Only providers whose templates are hashed by ResourceInputHash can safely
+// skip unchanged resources.
+type cachedGenerator interface {
+	// Returns the cache key of a resource. An empty resourceName gives the
+	// prefix shared by every resource of the product.
+	generationCacheKey(resourceName string) string
+
+	// Returns the options that the generated files depend on beyond the
+	// resource and product.
+	generationCacheOptions(generateCode, generateDocs bool) []string
+}
+
+// Generates every resource of p with g, skipping resources not in version
+// and, if resourceToGenerate is set, every other resource. Then generates the
+// product files if g is a ProductGenerator. versionName is the version being
+// built, which version is the closest match to.
+func GenerateObjects(g ResourceGenerator, p *api.Product, version *product.Version, versionName, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+	cached, _ := g.(cachedGenerator)
+	if generationCache == nil {
+		cached = nil
+	}
+
+	var cacheKeys []string
+	for _, object := range p.Objects {
+		object.ExcludeIfNotInVersion(version)
+
+		if cached != nil {
+			cacheKeys = append(cacheKeys, cached.generationCacheKey(object.Name))
+		}
+
+		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+			log.Printf("Excluding %s per user request", object.Name)
+			continue
+		}
+
+		if cached == nil {
+			g.GenerateObject(*object, outputFolder, versionName, generateCode, generateDocs)
+			continue
+		}
+
+		cacheKey := cached.generationCacheKey(object.Name)
+		inputHash, err := ResourceInputHash(*object, p, cached.generationCacheOptions(generateCode, generateDocs)...)
+		if err != nil {
+			log.Printf("Cannot hash inputs of %s, regenerating: %v", object.Name, err)
+			g.GenerateObject(*object, outputFolder, versionName, generateCode, generateDocs)
+			continue
+		}
+		if generationCache.Fresh(cacheKey, inputHash) {
+			log.Printf("%s is unchanged, skipping", object.Name)
+			continue
+		}
+
+		files := g.GenerateObject(*object, outputFolder, versionName, generateCode, generateDocs)
+		generationCache.Store(cacheKey, inputHash, files)
+	}
+
+	if cached != nil && resourceToGenerate == "" {
+		generationCache.Prune(cached.generationCacheKey(""), cacheKeys)
+	}
+
+	if productGenerator, ok := g.(ProductGenerator); ok {
+		productGenerator.GenerateProductFiles(outputFolder, generateCode, generateDocs)
+	}
+}
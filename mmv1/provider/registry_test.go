@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+)
+
+type fakeProvider struct {
+	calls *[]string
+}
+
+func (f fakeProvider) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+}
+
+func (f fakeProvider) CopyCommonFiles(outputFolder string, generateCode, generateDocs bool) {}
+
+func (f fakeProvider) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
+}
+
+func (f fakeProvider) GenerateObject(object api.Resource, outputFolder, versionName string, generateCode, generateDocs bool) []string {
+	*f.calls = append(*f.calls, "resource "+object.Name)
+	return nil
+}
+
+func (f fakeProvider) GenerateProductFiles(outputFolder string, generateCode, generateDocs bool) {
+	*f.calls = append(*f.calls, "product")
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	fake := Registration{
+		Name: "fake",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return fakeProvider{}
+		},
+	}
+	r := NewRegistry()
+	if err := r.Register(fake); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := r.New("fake", &api.Product{}, "ga", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.(fakeProvider); !ok {
+		t.Errorf("expected the registered provider, got %T", p)
+	}
+
+	if _, err := r.New("does_not_exist", &api.Product{}, "ga", time.Now()); err == nil {
+		t.Errorf("expected an error for an unknown provider")
+	}
+
+	cases := map[string]Registration{
+		"duplicate": fake,
+		"no name":   {New: fake.New},
+		"no New":    {Name: "no_new"},
+	}
+	for tn, registration := range cases {
+		if err := r.Register(registration); err == nil {
+			t.Errorf("%s: expected an error registering %+v", tn, registration)
+		}
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	p, err := New("", &api.Product{Versions: []*product.Version{{Name: "ga"}}}, "ga", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.(Terraform); !ok {
+		t.Errorf("expected the default provider to be Terraform, got %T", p)
+	}
+}
+
+func TestGenerateObjects(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description        string
+		resourceToGenerate string
+		expected           []string
+	}{
+		{"every resource", "", []string{"resource Subscription", "resource Topic", "product"}},
+		{"single resource", "Topic", []string{"resource Topic", "product"}},
+	}
+	for _, tc := range cases {
+		ga := &product.Version{Name: "ga"}
+		p := &api.Product{Versions: []*product.Version{ga}}
+		p.Objects = []*api.Resource{{Name: "Subscription", ProductMetadata: p}, {Name: "Topic", ProductMetadata: p}}
+		var calls []string
+		GenerateObjects(fakeProvider{calls: &calls}, p, ga, "ga", t.TempDir(), tc.resourceToGenerate, true, true)
+		if !reflect.DeepEqual(calls, tc.expected) {
+			t.Errorf("%s: expected hooks %v, got %v", tc.description, tc.expected, calls)
+		}
+	}
+}
@@ -38,6 +38,9 @@ type TemplateData struct {
 	TerraformResourceDirectory string
 	TerraformProviderModule    string
 
+	// Files written through this TemplateData, shared between copies
+	generatedFiles *[]string
+
 	// TODO rewrite: is this needed?
 	//     # Information about the local environment
 	//     # (which formatters are enabled, start-time)
@@ -52,7 +55,7 @@ var PRIVATE_VERSION = "private"
 var goimportFiles sync.Map
 
 func NewTemplateData(outputFolder string, versionName string) *TemplateData {
-	td := TemplateData{OutputFolder: outputFolder, VersionName: versionName}
+	td := TemplateData{OutputFolder: outputFolder, VersionName: versionName, generatedFiles: &[]string{}}
 
 	if versionName == GA_VERSION {
 		td.TerraformResourceDirectory = "google"
@@ -85,6 +88,19 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+// Generates a resource served by the plugin framework provider, for
+// resources with plugin_framework set.
+func (td *TemplateData) GenerateFrameworkResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/resource_fw.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/schema_property_fw.go.tmpl",
+		"templates/terraform/expand_property_method_fw.go.tmpl",
+		"templates/terraform/flatten_property_method_fw.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
 func (td *TemplateData) GenerateMetadataFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/metadata.yaml.tmpl"
 	templates := []string{
@@ -182,6 +198,110 @@ func (td *TemplateData) GenerateIamPolicyTestFile(filePath string, resource api.
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+func (td *TemplateData) GenerateDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_datasource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_datasource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/schema_property_ephemeral_fw.go.tmpl",
+		"templates/terraform/expand_property_method_fw.go.tmpl",
+		"templates/terraform/flatten_property_method_fw.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateIdFunctionsDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/id_functions.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateDatasourceTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/examples/base_configs/datasource_test_file.go.tmpl"
+	templates := []string{
+		"templates/terraform/env_var_context.go.tmpl",
+		templatePath,
+	}
+	tmplInput := TestInput{
+		Res:                  resource,
+		ImportPath:           td.ImportPath(),
+		PROJECT_NAME:         "my-project-name",
+		CREDENTIALS:          "my/credentials/filename.json",
+		REGION:               "us-west1",
+		ORG_ID:               "123456789",
+		ORG_DOMAIN:           "example.com",
+		ORG_TARGET:           "123456789",
+		PROJECT_NUMBER:       "1111111111111",
+		BILLING_ACCT:         "000000-0000000-0000000-000000",
+		MASTER_BILLING_ACCT:  "000000-0000000-0000000-000000",
+		SERVICE_ACCT:         "my@service-account.com",
+		CUST_ID:              "A01b123xz",
+		IDENTITY_USER:        "cloud_identity_user",
+		PAP_DESCRIPTION:      "description",
+		CHRONICLE_ID:         "00000000-0000-0000-0000-000000000000",
+		VMWAREENGINE_PROJECT: "my-vmwareengine-project",
+	}
+
+	td.GenerateFile(filePath, templatePath, tmplInput, true, templates...)
+}
+
 func (td *TemplateData) GenerateSweeperFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/sweeper_file.go.tmpl"
 	templates := []string{
@@ -258,10 +378,21 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 		}
 	}
 
-	err = os.WriteFile(filePath, sourceByte, 0644)
+	err = writeOutputFile(filePath, sourceByte, 0644)
 	if err != nil {
 		glog.Exit(err)
 	}
+	if td.generatedFiles != nil {
+		*td.generatedFiles = append(*td.generatedFiles, filePath)
+	}
+}
+
+// Returns the files written by GenerateFile so far.
+func (td *TemplateData) GeneratedFiles() []string {
+	if td.generatedFiles == nil {
+		return nil
+	}
+	return *td.generatedFiles
 }
 
 func (td *TemplateData) ImportPath() string {
@@ -276,6 +407,11 @@ func (td *TemplateData) ImportPath() string {
 func FixImports(outputPath string, dumpDiffs bool) {
 	log.Printf("Fixing go import paths")
 
+	if dryRun != nil {
+		dryRun.fixImports()
+		return
+	}
+
 	baseArgs := []string{"-w"}
 	if dumpDiffs {
 		baseArgs = []string{"-d", "-w"}
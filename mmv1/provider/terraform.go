@@ -39,8 +39,14 @@ type Terraform struct {
 
 	IAMResourceCount int
 
+	DatasourceCount int
+
 	ResourcesForVersion []map[string]string
 
+	// The API paths of the sensitive fields of the generated resources, to
+	// redact from logged requests.
+	SensitiveApiFields []string
+
 	TargetVersionName string
 
 	Version product.Version
@@ -54,6 +60,7 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 	t := Terraform{
 		ResourceCount:     0,
 		IAMResourceCount:  0,
+		DatasourceCount:   0,
 		Product:           product,
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
@@ -70,33 +77,43 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 	return t
 }
 
+func init() {
+	Register(Registration{
+		Name:        DefaultProvider,
+		Description: "terraform-provider-google and terraform-provider-google-beta",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraform(product, versionName, startTime)
+		},
+	})
+}
+
 func (t Terraform) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
-	t.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	GenerateObjects(&t, t.Product, &t.Version, t.TargetVersionName, outputFolder, resourceToGenerate, generateCode, generateDocs)
+}
 
+func (t *Terraform) GenerateProductFiles(outputFolder string, generateCode, generateDocs bool) {
 	if generateCode {
 		t.GenerateProduct(outputFolder)
 		t.GenerateOperation(outputFolder)
 	}
 }
 
-func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	for _, object := range t.Product.Objects {
-		object.ExcludeIfNotInVersion(&t.Version)
-
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
-			continue
-		}
+// Returns the generation cache key for a resource of this product. An empty
+// resourceName gives the prefix shared by every resource of the product.
+func (t *Terraform) generationCacheKey(resourceName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", ProviderName(*t), t.TargetVersionName, t.Product.Name, resourceName)
+}
 
-		t.GenerateObject(*object, outputFolder, t.TargetVersionName, generateCode, generateDocs)
-	}
+func (t *Terraform) generationCacheOptions(generateCode, generateDocs bool) []string {
+	return []string{ProviderName(*t), t.TargetVersionName, fmt.Sprintf("code=%t", generateCode), fmt.Sprintf("docs=%t", generateDocs)}
 }
 
-func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) {
+// Generates the files for a single resource and returns their paths.
+func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) []string {
 	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
 
 	if !object.IsExcluded() {
@@ -113,27 +130,51 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 	}
 
 	// if iam_policy is not defined or excluded, don't generate it
-	if object.IamPolicy == nil || object.IamPolicy.Exclude {
-		return
+	if object.IamPolicy != nil && !object.IamPolicy.Exclude {
+		t.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
 	}
 
-	t.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
+	if object.GeneratesDatasource() {
+		t.GenerateDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GeneratesListDatasource() {
+		t.GenerateListDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GeneratesListResource() {
+		t.GenerateListResource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GeneratesEphemeral() {
+		t.GenerateEphemeral(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
+	if object.GeneratesIdFunctions() && generateDocs {
+		t.GenerateIdFunctionsDocumentation(object, *templateData, outputFolder)
+	}
+
+	return templateData.GeneratedFiles()
 }
 
 func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	if generateCode {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
-		templateData.GenerateResourceFile(targetFilePath, object)
+		if object.PluginFramework {
+			templateData.GenerateFrameworkResourceFile(targetFilePath, object)
+		} else {
+			templateData.GenerateResourceFile(targetFilePath, object)
+		}
 	}
 
 	if generateDocs {
 		targetFolder := path.Join(outputFolder, "website", "docs", "r")
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
@@ -144,7 +185,7 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) {
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_meta.yaml", t.FullResourceName(object)))
@@ -167,7 +208,7 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_generated_test.go", t.ResourceGoFilename(object)))
@@ -181,7 +222,7 @@ func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData Te
 
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_sweeper.go", t.ResourceGoFilename(object)))
@@ -193,7 +234,7 @@ func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData Te
 // specific to the product.
 func (t *Terraform) GenerateProduct(outputFolder string) {
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 
@@ -212,7 +253,7 @@ func (t *Terraform) GenerateOperation(outputFolder string) {
 	}
 
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
-	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 	}
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_operation.go", google.Underscore(t.Product.Name)))
@@ -226,7 +267,7 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 	if generateCode && object.IamPolicy != nil && (object.IamPolicy.MinVersion == "" || slices.Index(product.ORDER, object.IamPolicy.MinVersion) <= slices.Index(product.ORDER, t.TargetVersionName)) {
 		productName := t.Product.ApiName
 		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
-		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("iam_%s.go", t.ResourceGoFilename(object)))
@@ -248,20 +289,122 @@ func (t *Terraform) GenerateIamPolicy(object api.Resource, templateData Template
 
 func (t *Terraform) GenerateIamDocumentation(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
 	resourceDocFolder := path.Join(outputFolder, "website", "docs", "r")
-	if err := os.MkdirAll(resourceDocFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(resourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourceDocFolder, err))
 	}
 	targetFilePath := path.Join(resourceDocFolder, fmt.Sprintf("%s_iam.html.markdown", t.FullResourceName(object)))
 	templateData.GenerateIamResourceDocumentationFile(targetFilePath, object)
 
 	datasourceDocFolder := path.Join(outputFolder, "website", "docs", "d")
-	if err := os.MkdirAll(datasourceDocFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(datasourceDocFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", datasourceDocFolder, err))
 	}
 	targetFilePath = path.Join(datasourceDocFolder, fmt.Sprintf("%s_iam_policy.html.markdown", t.FullResourceName(object)))
 	templateData.GenerateIamDatasourceDocumentationFile(targetFilePath, object)
 }
 
+func (t *Terraform) GenerateDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateDatasourceFile(targetFilePath, object)
+
+		// The test creates the resource from its first testable example.
+		if !object.Datasource.ExcludeTest && len(object.TestExamples()) != 0 {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s_generated_test.go", t.ResourceGoFilename(object)))
+			templateData.GenerateDatasourceTestFile(targetFilePath, object)
+		}
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GenerateListDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", google.PluralNoun(t.ResourceGoFilename(object))))
+		templateData.GenerateListDatasourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", google.PluralNoun(t.FullResourceName(object))))
+		templateData.GenerateListDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("list_resource_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateListResourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "list-resources")
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateListResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+func (t *Terraform) GenerateEphemeral(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	filename := strings.TrimPrefix(object.EphemeralTerraformName(), "google_")
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s.go", filename))
+		templateData.GenerateEphemeralFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "ephemeral-resources")
+		if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", filename))
+		templateData.GenerateEphemeralDocumentationFile(targetFilePath, object)
+	}
+}
+
+// The id functions are registered by the plugin framework provider from
+// ResourcesForVersion, so only their documentation is generated per resource.
+func (t *Terraform) GenerateIdFunctionsDocumentation(object api.Resource, templateData TemplateData, outputFolder string) {
+	targetFolder := path.Join(outputFolder, "website", "docs", "functions")
+	if err := makeOutputDir(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", object.IdFunctionName()))
+	templateData.GenerateIdFunctionsDocumentationFile(targetFilePath, object)
+}
+
 // Finds the folder name for a given version of the terraform provider
 func (t *Terraform) FolderName() string {
 	if t.TargetVersionName == "ga" {
@@ -393,7 +536,7 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
 
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := makeOutputDir(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 		// If we've modified a file since starting an MM run, it's a reasonable
@@ -414,7 +557,7 @@ func (t Terraform) CopyFileList(outputFolder string, files map[string]string, ge
 			permission = 0644
 		}
 
-		err = os.WriteFile(targetFile, sourceByte, permission)
+		err = writeOutputFile(targetFile, sourceByte, permission)
 		if err != nil {
 			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
 		}
@@ -502,14 +645,14 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 		Products:  products,
 	}
 
-	if err := os.MkdirAll(outputFolder, os.ModePerm); err != nil {
+	if err := makeOutputDir(outputFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating output directory %v: %v", outputFolder, err))
 	}
 
 	for target, source := range files {
 		targetFile := filepath.Join(outputFolder, target)
 		targetDir := filepath.Dir(targetFile)
-		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+		if err := makeOutputDir(targetDir, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating output directory %v: %v", targetDir, err))
 		}
 
@@ -521,7 +664,7 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
 		// continue to next file if no file was generated
-		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
+		if !outputFileExists(targetFile) {
 			continue
 		}
 		t.replaceImportPath(outputFolder, target)
@@ -539,7 +682,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 	}
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := readOutputFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add copy file header: %s", targetFile, err)
 	}
@@ -583,7 +726,7 @@ func (t Terraform) addCopyfileHeader(srcpath, outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutputFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add copy file header: %s", target, err)
 	}
@@ -643,13 +786,13 @@ func (t Terraform) addHashicorpCopyRightHeader(outputFolder, target string) {
 	header := commentBlock(copyrightHeader, lang)
 
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := readOutputFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to add Hashicorp copy right: %s", targetFile, err)
 	}
 
 	sourceByte = google.Concat([]byte(header), sourceByte)
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutputFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to add Hashicorp copy right: %s", target, err)
 	}
@@ -671,7 +814,7 @@ func expectedOutputFolder(outputFolder string) bool {
 
 func (t Terraform) replaceImportPath(outputFolder, target string) {
 	targetFile := filepath.Join(outputFolder, target)
-	sourceByte, err := os.ReadFile(targetFile)
+	sourceByte, err := readOutputFile(targetFile)
 	if err != nil {
 		log.Fatalf("Cannot read file %s to replace import path: %s", targetFile, err)
 	}
@@ -714,7 +857,7 @@ func (t Terraform) replaceImportPath(outputFolder, target string) {
 		}
 	}
 
-	err = os.WriteFile(targetFile, sourceByte, 0644)
+	err = writeOutputFile(targetFile, sourceByte, 0644)
 	if err != nil {
 		log.Fatalf("Cannot write file %s to replace import path: %s", target, err)
 	}
@@ -761,13 +904,64 @@ func (t Terraform) GetMmv1ServicesInVersion(products []*api.Product) []string {
 	return services
 }
 
+// Returns the services with a generated list resource, which are imported by
+// the plugin framework provider.
+func (t Terraform) GetListResourceServicesInVersion(products []*api.Product) []string {
+	return t.servicesInVersion(products, func(object *api.Resource) bool {
+		return object.GeneratesListResource()
+	})
+}
+
+// Returns the services with a resource generated with the plugin framework,
+// which are imported by the plugin framework provider.
+func (t Terraform) GetFrameworkResourceServicesInVersion(products []*api.Product) []string {
+	return t.servicesInVersion(products, func(object *api.Resource) bool {
+		return object.PluginFramework && !object.IsExcluded()
+	})
+}
+
+// Returns the services with a generated ephemeral resource, which are
+// imported by the plugin framework provider.
+func (t Terraform) GetEphemeralServicesInVersion(products []*api.Product) []string {
+	return t.servicesInVersion(products, func(object *api.Resource) bool {
+		return object.GeneratesEphemeral()
+	})
+}
+
+// Returns the services with at least one resource in the version matching
+// generates.
+func (t Terraform) servicesInVersion(products []*api.Product, generates func(*api.Resource) bool) []string {
+	var services []string
+	for _, product := range products {
+		for _, object := range product.Objects {
+			if object.Exclude || object.NotInVersion(product.VersionObjOrClosest(t.TargetVersionName)) {
+				continue
+			}
+			if generates(object) {
+				services = append(services, strings.ToLower(product.Name))
+				break
+			}
+		}
+	}
+	return services
+}
+
 // # Generates the list of resources, and gets the count of resources and iam resources
 // # dependent on the version ga, beta or private.
 // # The resource object has the format
 // # {
 // #    terraform_name:
 // #    resource_name:
+// #    framework_resource_name:
 // #    iam_class_name:
+// #    datasource_name:
+// #    list_datasource_name:
+// #    list_datasource_terraform_name:
+// #    list_resource_name:
+// #    ephemeral_name:
+// #    id_function_name:
+// #    parse_id_function_name:
+// #    id_format:
 // # }
 // # The variable resources_for_version is used to generate resources in file
 // # mmv1/third_party/terraform/provider/provider_mmv1_resources.go.erb
@@ -779,11 +973,16 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
-			var resourceName string
+			var resourceName, frameworkResourceName string
 
 			if !object.IsExcluded() {
 				t.ResourceCount++
-				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				t.SensitiveApiFields = append(t.SensitiveApiFields, object.SensitiveApiFields()...)
+				if object.PluginFramework {
+					frameworkResourceName = fmt.Sprintf("%s.%s", service, object.FrameworkResourceFunctionName())
+				} else {
+					resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				}
 			}
 
 			var iamClassName string
@@ -796,13 +995,52 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				}
 			}
 
+			var datasourceName string
+			if object.GeneratesDatasource() {
+				t.DatasourceCount++
+				datasourceName = fmt.Sprintf("%s.%s", service, object.DatasourceFunctionName())
+			}
+
+			var listDatasourceName string
+			if object.GeneratesListDatasource() {
+				t.DatasourceCount++
+				listDatasourceName = fmt.Sprintf("%s.%s", service, object.ListDatasourceFunctionName())
+			}
+
+			var listResourceName string
+			if object.GeneratesListResource() {
+				listResourceName = fmt.Sprintf("%s.%s", service, object.ListResourceFunctionName())
+			}
+
+			var ephemeralName string
+			if object.GeneratesEphemeral() {
+				ephemeralName = fmt.Sprintf("%s.%s", service, object.EphemeralFunctionName())
+			}
+
+			var idFunctionName, parseIdFunctionName string
+			if object.GeneratesIdFunctions() {
+				idFunctionName = object.IdFunctionName()
+				parseIdFunctionName = object.ParseIdFunctionName()
+			}
+
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":               object.TerraformName(),
+				"ResourceName":                resourceName,
+				"FrameworkResourceName":       frameworkResourceName,
+				"IamClassName":                iamClassName,
+				"DatasourceName":              datasourceName,
+				"ListDatasourceName":          listDatasourceName,
+				"ListDatasourceTerraformName": object.ListDatasourceTerraformName(),
+				"ListResourceName":            listResourceName,
+				"EphemeralName":               ephemeralName,
+				"IdFunctionName":              idFunctionName,
+				"ParseIdFunctionName":         parseIdFunctionName,
+				"IdFormat":                    object.GetIdFormat(),
 			})
 		}
 	}
+	slices.Sort(t.SensitiveApiFields)
+	t.SensitiveApiFields = slices.Compact(t.SensitiveApiFields)
 }
 
 // # Adapted from the method used in templating
@@ -49,30 +49,28 @@ func NewTerraformOiCS(product *api.Product, versionName string, startTime time.T
 	return toics
 }
 
-func (toics TerraformOiCS) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
-	toics.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+func init() {
+	Register(Registration{
+		Name:        "oics",
+		Description: "Open in Cloud Shell examples",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformOiCS(product, versionName, startTime)
+		},
+	})
 }
 
-func (toics TerraformOiCS) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	for _, object := range toics.Product.Objects {
-		object.ExcludeIfNotInVersion(&toics.Version)
-
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
-			continue
-		}
-
-		toics.GenerateObject(*object, outputFolder, toics.TargetVersionName, generateCode, generateDocs)
-	}
+func (toics TerraformOiCS) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+	GenerateObjects(toics, toics.Product, &toics.Version, toics.TargetVersionName, outputFolder, resourceToGenerate, generateCode, generateDocs)
 }
 
-func (toics TerraformOiCS) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (toics TerraformOiCS) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) []string {
 	templateData := NewTemplateData(outputFolder, toics.TargetVersionName)
 
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
 		toics.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
 	}
+	return templateData.GeneratedFiles()
 }
 
 func (toics TerraformOiCS) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
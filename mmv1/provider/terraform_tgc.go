@@ -69,6 +69,16 @@ func NewTerraformGoogleConversion(product *api.Product, versionName string, star
 	return t
 }
 
+func init() {
+	Register(Registration{
+		Name:        "tgc",
+		Description: "terraform-google-conversion tfplan2cai converters",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformGoogleConversion(product, versionName, startTime)
+		},
+	})
+}
+
 func (tgc TerraformGoogleConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
 	// Temporary shim to generate the missing resources directory. Can be removed
 	// once the folder exists downstream.
@@ -76,26 +86,13 @@ func (tgc TerraformGoogleConversion) Generate(outputFolder, productPath, resourc
 	if err := os.MkdirAll(resourcesFolder, os.ModePerm); err != nil {
 		log.Println(fmt.Errorf("error creating parent directory %v: %v", resourcesFolder, err))
 	}
-	tgc.GenerateObjects(outputFolder, resourceToGenerate, generateCode, generateDocs)
+	GenerateObjects(tgc, tgc.Product, &tgc.Version, tgc.TargetVersionName, outputFolder, resourceToGenerate, generateCode, generateDocs)
 }
 
-func (tgc TerraformGoogleConversion) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
-	for _, object := range tgc.Product.Objects {
-		object.ExcludeIfNotInVersion(&tgc.Version)
-
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
-			continue
-		}
-
-		tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs)
-	}
-}
-
-func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) []string {
 	if object.ExcludeTgc {
 		log.Printf("Skipping fine-grained resource %s", object.Name)
-		return
+		return nil
 	}
 
 	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
@@ -111,10 +108,11 @@ func (tgc TerraformGoogleConversion) GenerateObject(object api.Resource, outputF
 
 	// if iam_policy is not defined or excluded, don't generate it
 	if object.IamPolicy == nil || object.IamPolicy.Exclude {
-		return
+		return templateData.GeneratedFiles()
 	}
 
 	tgc.GenerateIamPolicy(object, *templateData, outputFolder, generateCode, generateDocs)
+	return templateData.GeneratedFiles()
 }
 
 func (tgc TerraformGoogleConversion) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
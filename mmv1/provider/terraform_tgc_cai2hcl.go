@@ -53,6 +53,16 @@ func NewCaiToTerraformConversion(product *api.Product, versionName string, start
 	return t
 }
 
+func init() {
+	Register(Registration{
+		Name:        "tgc_cai2hcl",
+		Description: "terraform-google-conversion cai2hcl converters",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewCaiToTerraformConversion(product, versionName, startTime)
+		},
+	})
+}
+
 func (cai2hcl CaiToTerraformConversion) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
 }
 
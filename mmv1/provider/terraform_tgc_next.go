@@ -78,22 +78,23 @@ func NewTerraformGoogleConversionNext(product *api.Product, versionName string,
 	return t
 }
 
-func (tgc TerraformGoogleConversionNext) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
-	for _, object := range tgc.Product.Objects {
-		object.ExcludeIfNotInVersion(&tgc.Version)
-
-		if resourceToGenerate != "" && object.Name != resourceToGenerate {
-			log.Printf("Excluding %s per user request", object.Name)
-			continue
-		}
+func init() {
+	Register(Registration{
+		Name:        "tgc_next",
+		Description: "terraform-google-conversion tfplan2cai and cai2hcl converters, next generation",
+		New: func(product *api.Product, versionName string, startTime time.Time) Provider {
+			return NewTerraformGoogleConversionNext(product, versionName, startTime)
+		},
+	})
+}
 
-		tgc.GenerateObject(*object, outputFolder, tgc.TargetVersionName, generateCode, generateDocs)
-	}
+func (tgc TerraformGoogleConversionNext) Generate(outputFolder, productPath, resourceToGenerate string, generateCode, generateDocs bool) {
+	GenerateObjects(tgc, tgc.Product, &tgc.Version, tgc.TargetVersionName, outputFolder, resourceToGenerate, generateCode, generateDocs)
 }
 
-func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, outputFolder, resourceToGenerate string, generateCode, generateDocs bool) []string {
 	if !object.IncludeInTGCNext {
-		return
+		return nil
 	}
 
 	templateData := NewTemplateData(outputFolder, tgc.TargetVersionName)
@@ -102,6 +103,7 @@ func (tgc TerraformGoogleConversionNext) GenerateObject(object api.Resource, out
 		tgc.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
 		tgc.GenerateResourceTests(object, *templateData, outputFolder)
 	}
+	return templateData.GeneratedFiles()
 }
 
 func (tgc TerraformGoogleConversionNext) GenerateResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
@@ -0,0 +1,213 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Matches the fields of an id format, eg: {{project}}. Fields starting with %
+// can contain slashes.
+var resourceIdFieldRegex = regexp.MustCompile(`\{\{(%?)(\w+)\}\}`)
+
+// ResourceIdFields returns the fields of an id format, eg: project, zone and
+// name for "projects/{{project}}/zones/{{zone}}/instances/{{name}}".
+func ResourceIdFields(format string) []string {
+	var fields []string
+	for _, match := range resourceIdFieldRegex.FindAllStringSubmatch(format, -1) {
+		if !slices.Contains(fields, match[2]) {
+			fields = append(fields, match[2])
+		}
+	}
+	return fields
+}
+
+// ResourceIdRegex returns the regular expression matching ids in format, as
+// well as self links and OP style resource names ending with one. Each field
+// is a named submatch.
+func ResourceIdRegex(format string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf("(?:^|/)%s$", resourceIdPattern(format)))
+}
+
+func resourceIdPattern(format string) string {
+	var pattern strings.Builder
+	last := 0
+	for _, match := range resourceIdFieldRegex.FindAllStringSubmatchIndex(format, -1) {
+		pattern.WriteString(regexp.QuoteMeta(format[last:match[0]]))
+		field := format[match[4]:match[5]]
+		if match[3] > match[2] {
+			pattern.WriteString(fmt.Sprintf("(?P<%s>.+)", field))
+		} else {
+			pattern.WriteString(fmt.Sprintf("(?P<%s>[^/]+)", field))
+		}
+		last = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(format[last:]))
+	return pattern.String()
+}
+
+var _ function.Function = ResourceIdFunction{}
+
+// NewResourceIdFunction returns a function building the id of resource from
+// the fields of its id format.
+func NewResourceIdFunction(name, resource, format string) function.Function {
+	return &ResourceIdFunction{
+		name:     name,
+		resource: resource,
+		format:   format,
+	}
+}
+
+type ResourceIdFunction struct {
+	name     string // Makes function name available in Run logic for logging purposes
+	resource string
+	format   string
+}
+
+func (f ResourceIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f ResourceIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	fields := ResourceIdFields(f.format)
+	var params []function.Parameter
+	for _, field := range fields {
+		params = append(params, function.StringParameter{
+			Name:        field,
+			Description: fmt.Sprintf("The %s of the %s.", field, f.resource),
+		})
+	}
+
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Returns the id of a %s.", f.resource),
+		Description: fmt.Sprintf("Takes the %s of a %s and returns its id in the format \"%s\". Raises an error if a value is empty or contains a \"/\" where the format does not allow one.", strings.Join(fields, ", "), f.resource, f.format),
+		Parameters:  params,
+		Return:      function.StringReturn{},
+	}
+}
+
+func (f ResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	// Load arguments from function call
+	fields := ResourceIdFields(f.format)
+	values := make([]string, len(fields))
+	targets := make([]any, len(fields))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, targets...))
+	if resp.Error != nil {
+		return
+	}
+
+	// Validate input
+	for i, value := range values {
+		if value == "" {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("The %s of the %s must not be empty.", fields[i], f.resource))
+			return
+		}
+	}
+
+	// Build the id and check that the values did not change its structure
+	id := resourceIdFieldRegex.ReplaceAllStringFunc(f.format, func(match string) string {
+		return values[slices.Index(fields, resourceIdFieldRegex.FindStringSubmatch(match)[2])]
+	})
+	regex := regexp.MustCompile(fmt.Sprintf("^%s$", resourceIdPattern(f.format)))
+	if submatches := regex.FindStringSubmatch(id); submatches == nil || !slices.Equal(submatches[1:], resourceIdSubmatches(f.format, fields, values)) {
+		resp.Error = function.NewFuncError(fmt.Sprintf("The id \"%s\" built from the arguments doesn't match the pattern \"%s\" of %s ids. Check that the arguments don't contain \"/\".", id, f.format, f.resource))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, id))
+}
+
+// Returns the values of the fields in the order they appear in format.
+func resourceIdSubmatches(format string, fields, values []string) []string {
+	var submatches []string
+	for _, match := range resourceIdFieldRegex.FindAllStringSubmatch(format, -1) {
+		submatches = append(submatches, values[slices.Index(fields, match[2])])
+	}
+	return submatches
+}
+
+var _ function.Function = ParseResourceIdFunction{}
+
+// NewParseResourceIdFunction returns a function returning the fields of the
+// id format of resource from an id, self link or OP style resource name.
+func NewParseResourceIdFunction(name, resource, format string) function.Function {
+	return &ParseResourceIdFunction{
+		name:     name,
+		resource: resource,
+		format:   format,
+	}
+}
+
+type ParseResourceIdFunction struct {
+	name     string // Makes function name available in Run logic for logging purposes
+	resource string
+	format   string
+}
+
+func (f ParseResourceIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f ParseResourceIdFunction) attributeTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type)
+	for _, field := range ResourceIdFields(f.format) {
+		attrTypes[field] = types.StringType
+	}
+	return attrTypes
+}
+
+func (f ParseResourceIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Returns the fields of a %s id.", f.resource),
+		Description: fmt.Sprintf("Takes a single string argument, which should be the id, self link or OP style resource name of a %s, and returns an object with its %s. Raises an error if the argument doesn't match the pattern \"%s\".", f.resource, strings.Join(ResourceIdFields(f.format), ", "), f.format),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: fmt.Sprintf("The id, self link or OP style resource name of a %s, in the format \"%s\".", f.resource, f.format),
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: f.attributeTypes(),
+		},
+	}
+}
+
+func (f ParseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	// Load arguments from function call
+	var arg0 string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.GetArgument(ctx, 0, &arg0))
+	if resp.Error != nil {
+		return
+	}
+
+	// Validate input
+	regex := ResourceIdRegex(f.format)
+	submatches := regex.FindStringSubmatch(arg0)
+	if submatches == nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The input string \"%s\" doesn't match the pattern \"%s\" of %s ids.", arg0, f.format, f.resource))
+		return
+	}
+
+	attrs := make(map[string]attr.Value)
+	for i, field := range regex.SubexpNames() {
+		if field != "" {
+			attrs[field] = types.StringValue(submatches[i])
+		}
+	}
+	result, diags := types.ObjectValue(f.attributeTypes(), attrs)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,161 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const testResourceIdFormat = "projects/{{project}}/zones/{{zone}}/instances/{{name}}"
+
+func TestFunctionRun_resource_id(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request  function.RunRequest
+		expected function.RunResponse
+	}{
+		"it returns the id built from the arguments": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("my-project"), types.StringValue("us-central1-c"), types.StringValue("foobar")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringValue("projects/my-project/zones/us-central1-c/instances/foobar")),
+			},
+		},
+		"it returns an error when given an empty argument": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("my-project"), types.StringValue(""), types.StringValue("foobar")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringNull()),
+				Error:  function.NewArgumentFuncError(1, "The zone of the google_compute_instance must not be empty."),
+			},
+		},
+		"it returns an error when an argument changes the structure of the id": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("my-project"), types.StringValue("us-central1-c"), types.StringValue("foo/bar")}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.StringNull()),
+				Error:  function.NewFuncError(fmt.Sprintf("The id \"projects/my-project/zones/us-central1-c/instances/foo/bar\" built from the arguments doesn't match the pattern \"%s\" of google_compute_instance ids. Check that the arguments don't contain \"/\".", testResourceIdFormat)),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		tn, tc := name, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			got := function.RunResponse{
+				Result: function.NewResultData(basetypes.StringValue{}),
+			}
+
+			// Act
+			NewResourceIdFunction("compute_instance_id", "google_compute_instance", testResourceIdFormat).Run(context.Background(), tc.request, &got)
+
+			// Assert
+			if diff := cmp.Diff(got.Result, tc.expected.Result); diff != "" {
+				t.Errorf("unexpected diff between expected and received result: %s", diff)
+			}
+			if diff := cmp.Diff(got.Error, tc.expected.Error); diff != "" {
+				t.Errorf("unexpected diff between expected and received errors: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFunctionRun_parse_resource_id(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{
+		"project": types.StringType,
+		"zone":    types.StringType,
+		"name":    types.StringType,
+	}
+	expected := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"project": types.StringValue("my-project"),
+		"zone":    types.StringValue("us-central1-c"),
+		"name":    types.StringValue("foobar"),
+	})
+
+	// Happy path inputs
+	validId := "projects/my-project/zones/us-central1-c/instances/foobar"
+	validSelfLink := fmt.Sprintf("https://www.googleapis.com/compute/v1/%s", validId)
+	validOpStyleResourceName := fmt.Sprintf("//compute.googleapis.com/%s", validId)
+
+	// Unhappy path inputs
+	invalidInput := "projects/my-project/instances/foobar"
+
+	testCases := map[string]struct {
+		request  function.RunRequest
+		expected function.RunResponse
+	}{
+		"it returns the expected output value when given a valid resource id input": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(validId)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(expected),
+			},
+		},
+		"it returns the expected output value when given a valid resource self_link input": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(validSelfLink)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(expected),
+			},
+		},
+		"it returns the expected output value when given a valid OP style resource name input": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(validOpStyleResourceName)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(expected),
+			},
+		},
+		"it returns an error when given input that doesn't match the id format": {
+			request: function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(invalidInput)}),
+			},
+			expected: function.RunResponse{
+				Result: function.NewResultData(types.ObjectNull(attrTypes)),
+				Error:  function.NewArgumentFuncError(0, fmt.Sprintf("The input string \"%s\" doesn't match the pattern \"%s\" of google_compute_instance ids.", invalidInput, testResourceIdFormat)),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		tn, tc := name, testCase
+
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			got := function.RunResponse{
+				Result: function.NewResultData(types.ObjectNull(attrTypes)),
+			}
+
+			// Act
+			NewParseResourceIdFunction("parse_compute_instance_id", "google_compute_instance", testResourceIdFormat).Run(context.Background(), tc.request, &got)
+
+			// Assert
+			if diff := cmp.Diff(got.Result, tc.expected.Result); diff != "" {
+				t.Errorf("unexpected diff between expected and received result: %s", diff)
+			}
+			if diff := cmp.Diff(got.Error, tc.expected.Error); diff != "" {
+				t.Errorf("unexpected diff between expected and received errors: %s", diff)
+			}
+		})
+	}
+}
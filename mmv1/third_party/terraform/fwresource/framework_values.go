@@ -0,0 +1,143 @@
+package fwresource
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+)
+
+// ExpandValue returns the API value of a primitive, list or map value of a
+// plugin framework resource, or nil if the value is null or unknown. Objects
+// are expanded by the generated expanders of the resource.
+func ExpandValue(v attr.Value) interface{} {
+	if v == nil || v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Bool:
+		return v.ValueBool()
+	case types.Int64:
+		return v.ValueInt64()
+	case types.Float64:
+		return v.ValueFloat64()
+	case types.List:
+		l := make([]interface{}, 0, len(v.Elements()))
+		for _, e := range v.Elements() {
+			l = append(l, ExpandValue(e))
+		}
+		return l
+	case types.Map:
+		m := make(map[string]interface{}, len(v.Elements()))
+		for k, e := range v.Elements() {
+			m[k] = ExpandValue(e)
+		}
+		return m
+	}
+	return nil
+}
+
+// FlattenValue returns the plugin framework value of type t for a primitive,
+// list or map API value. Empty API values are flattened to null, unless the
+// prior value from the plan or state is empty too, so fields set to their
+// zero value in the configuration keep it.
+func FlattenValue(v interface{}, prior attr.Value, t attr.Type, diags *diag.Diagnostics) attr.Value {
+	if tpgresource.IsEmptyValue(reflect.ValueOf(v)) {
+		if prior != nil && !prior.IsNull() && !prior.IsUnknown() && tpgresource.IsEmptyValue(reflect.ValueOf(ExpandValue(prior))) {
+			return prior
+		}
+		return NullValue(t)
+	}
+
+	switch t := t.(type) {
+	case basetypes.StringType:
+		if s, ok := v.(string); ok {
+			return types.StringValue(s)
+		}
+	case basetypes.BoolType:
+		if b, ok := v.(bool); ok {
+			return types.BoolValue(b)
+		}
+	case basetypes.Int64Type:
+		// 64-bit integers are strings in API responses
+		switch n := v.(type) {
+		case float64:
+			return types.Int64Value(int64(n))
+		case string:
+			if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+				return types.Int64Value(i)
+			}
+		}
+	case basetypes.Float64Type:
+		switch n := v.(type) {
+		case float64:
+			return types.Float64Value(n)
+		case string:
+			if f, err := strconv.ParseFloat(n, 64); err == nil {
+				return types.Float64Value(f)
+			}
+		}
+	case basetypes.ListType:
+		if l, ok := v.([]interface{}); ok {
+			var priorElems []attr.Value
+			if prior, ok := prior.(types.List); ok {
+				priorElems = prior.Elements()
+			}
+			elems := make([]attr.Value, 0, len(l))
+			for i, e := range l {
+				var priorElem attr.Value
+				if i < len(priorElems) {
+					priorElem = priorElems[i]
+				}
+				elems = append(elems, FlattenValue(e, priorElem, t.ElemType, diags))
+			}
+			list, d := types.ListValue(t.ElemType, elems)
+			diags.Append(d...)
+			return list
+		}
+	case basetypes.MapType:
+		if m, ok := v.(map[string]interface{}); ok {
+			var priorElems map[string]attr.Value
+			if prior, ok := prior.(types.Map); ok {
+				priorElems = prior.Elements()
+			}
+			elems := make(map[string]attr.Value, len(m))
+			for k, e := range m {
+				elems[k] = FlattenValue(e, priorElems[k], t.ElemType, diags)
+			}
+			mapValue, d := types.MapValue(t.ElemType, elems)
+			diags.Append(d...)
+			return mapValue
+		}
+	}
+
+	diags.AddError("Error flattening value", fmt.Sprintf("Cannot flatten %#v into %s", v, t))
+	return NullValue(t)
+}
+
+// NullValue returns the null value of type t.
+func NullValue(t attr.Type) attr.Value {
+	switch t := t.(type) {
+	case basetypes.BoolType:
+		return types.BoolNull()
+	case basetypes.Int64Type:
+		return types.Int64Null()
+	case basetypes.Float64Type:
+		return types.Float64Null()
+	case basetypes.ListType:
+		return types.ListNull(t.ElemType)
+	case basetypes.MapType:
+		return types.MapNull(t.ElemType)
+	case basetypes.ObjectType:
+		return types.ObjectNull(t.AttrTypes)
+	}
+	return types.StringNull()
+}
@@ -0,0 +1,122 @@
+package fwresource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandValue(t *testing.T) {
+	cases := map[string]struct {
+		Value    attr.Value
+		Expected interface{}
+	}{
+		"null values are expanded to nil": {
+			Value:    types.StringNull(),
+			Expected: nil,
+		},
+		"unknown values are expanded to nil": {
+			Value:    types.Int64Unknown(),
+			Expected: nil,
+		},
+		"strings are expanded": {
+			Value:    types.StringValue("foo"),
+			Expected: "foo",
+		},
+		"integers are expanded": {
+			Value:    types.Int64Value(3),
+			Expected: int64(3),
+		},
+		"lists are expanded": {
+			Value:    types.ListValueMust(types.BoolType, []attr.Value{types.BoolValue(true), types.BoolValue(false)}),
+			Expected: []interface{}{true, false},
+		},
+		"maps are expanded": {
+			Value:    types.MapValueMust(types.StringType, map[string]attr.Value{"foo": types.StringValue("bar")}),
+			Expected: map[string]interface{}{"foo": "bar"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if v := ExpandValue(tc.Value); !reflect.DeepEqual(v, tc.Expected) {
+				t.Fatalf("want %#v, got %#v", tc.Expected, v)
+			}
+		})
+	}
+}
+
+func TestFlattenValue(t *testing.T) {
+	cases := map[string]struct {
+		Value         interface{}
+		Prior         attr.Value
+		Type          attr.Type
+		Expected      attr.Value
+		ExpectedError bool
+	}{
+		"strings are flattened": {
+			Value:    "foo",
+			Type:     types.StringType,
+			Expected: types.StringValue("foo"),
+		},
+		"integers sent as strings are flattened": {
+			Value:    "12345678901",
+			Type:     types.Int64Type,
+			Expected: types.Int64Value(12345678901),
+		},
+		"integers sent as numbers are flattened": {
+			Value:    float64(3),
+			Type:     types.Int64Type,
+			Expected: types.Int64Value(3),
+		},
+		"missing values are flattened to null": {
+			Value:    nil,
+			Prior:    types.BoolValue(true),
+			Type:     types.BoolType,
+			Expected: types.BoolNull(),
+		},
+		"missing values keep a prior zero value": {
+			Value:    nil,
+			Prior:    types.BoolValue(false),
+			Type:     types.BoolType,
+			Expected: types.BoolValue(false),
+		},
+		"lists are flattened": {
+			Value:    []interface{}{"foo", "bar"},
+			Type:     types.ListType{ElemType: types.StringType},
+			Expected: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("foo"), types.StringValue("bar")}),
+		},
+		"empty lists are flattened to null": {
+			Value:    []interface{}{},
+			Type:     types.ListType{ElemType: types.StringType},
+			Expected: types.ListNull(types.StringType),
+		},
+		"maps are flattened": {
+			Value:    map[string]interface{}{"foo": "bar"},
+			Type:     types.MapType{ElemType: types.StringType},
+			Expected: types.MapValueMust(types.StringType, map[string]attr.Value{"foo": types.StringValue("bar")}),
+		},
+		"values of the wrong type are errors": {
+			Value:         true,
+			Type:          types.StringType,
+			Expected:      types.StringNull(),
+			ExpectedError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+			v := FlattenValue(tc.Value, tc.Prior, tc.Type, &diags)
+			if diags.HasError() != tc.ExpectedError {
+				t.Fatalf("want error %t, got diagnostics %v", tc.ExpectedError, diags)
+			}
+			if !v.Equal(tc.Expected) {
+				t.Fatalf("want %s, got %s", tc.Expected, v)
+			}
+		})
+	}
+}
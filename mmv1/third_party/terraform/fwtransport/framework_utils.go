@@ -47,3 +47,16 @@ func HandleDatasourceNotFoundError(ctx context.Context, err error, state *tfsdk.
 
 	diags.AddError(fmt.Sprintf("Error when reading or editing %s", resource), err.Error())
 }
+
+// Removes a resource that is gone from the state instead of failing, like
+// transport_tpg.HandleNotFoundError does for SDK resources.
+func HandleResourceNotFoundError(ctx context.Context, err error, state *tfsdk.State, resource string, diags *diag.Diagnostics) {
+	if transport_tpg.IsGoogleApiErrorWithCode(err, 404) {
+		tflog.Warn(ctx, fmt.Sprintf("Removing %s because it's gone", resource))
+		// The resource doesn't exist anymore
+		state.RemoveResource(ctx)
+		return
+	}
+
+	diags.AddError(fmt.Sprintf("Error when reading or editing %s", resource), err.Error())
+}
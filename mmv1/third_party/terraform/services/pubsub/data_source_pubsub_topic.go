@@ -1,44 +0,0 @@
-package pubsub
-
-import (
-	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
-	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
-)
-
-func DataSourceGooglePubsubTopic() *schema.Resource {
-
-	dsSchema := tpgresource.DatasourceSchemaFromResourceSchema(ResourcePubsubTopic().Schema)
-	tpgresource.AddRequiredFieldsToSchema(dsSchema, "name")
-	tpgresource.AddOptionalFieldsToSchema(dsSchema, "project")
-
-	return &schema.Resource{
-		Read:   dataSourceGooglePubsubTopicRead,
-		Schema: dsSchema,
-	}
-}
-
-func dataSourceGooglePubsubTopicRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*transport_tpg.Config)
-
-	id, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/topics/{{name}}")
-	if err != nil {
-		return fmt.Errorf("Error constructing id: %s", err)
-	}
-	d.SetId(id)
-	err = resourcePubsubTopicRead(d, meta)
-	if err != nil {
-		return err
-	}
-
-	if err := tpgresource.SetDataSourceLabels(d); err != nil {
-		return err
-	}
-
-	if d.Id() == "" {
-		return fmt.Errorf("%s not found", id)
-	}
-	return nil
-}
@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -52,6 +53,15 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// ContextWaiter is a Waiter whose operation queries can be given a context,
+// eg: so that OperationWaitContext traces them under its poll spans.
+type ContextWaiter interface {
+	Waiter
+
+	// QueryOpContext is QueryOp, sending its requests with ctx.
+	QueryOpContext(ctx context.Context) (interface{}, error)
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
 }
@@ -108,8 +118,13 @@ func OperationDone(w Waiter) bool {
 }
 
 func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
+	return commonRefreshFunc(w, w.QueryOp)
+}
+
+// commonRefreshFunc is CommonRefreshFunc, querying the operation with queryOp.
+func commonRefreshFunc(w Waiter, queryOp func() (interface{}, error)) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		op, err := w.QueryOp()
+		op, err := queryOp()
 		if err != nil {
 			// Retry 404 when getting operation (not resource state)
 			if transport_tpg.IsRetryableError(err, []transport_tpg.RetryErrorPredicateFunc{transport_tpg.IsNotFoundRetryableError("GET operation")}, nil) {
@@ -137,19 +152,42 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 }
 
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+	return OperationWaitContext(context.Background(), w, activity, timeout, pollInterval)
+}
+
+// OperationWaitContext is OperationWait, cancelled with ctx and traced under
+// the span of ctx.
+func OperationWaitContext(ctx context.Context, w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
 	if OperationDone(w) {
 		return w.Error()
 	}
 
+	ctx, span := transport_tpg.StartSpan(ctx, "operation.wait", transport_tpg.Attr("activity", activity), transport_tpg.Attr("operation", w.OpName()))
+	err := operationWait(ctx, w, activity, timeout, pollInterval)
+	span.End(err)
+	return err
+}
+
+func operationWait(ctx context.Context, w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
 	c := &retry.StateChangeConf{
-		Pending:      w.PendingStates(),
-		Target:       w.TargetStates(),
-		Refresh:      CommonRefreshFunc(w),
+		Pending: w.PendingStates(),
+		Target:  w.TargetStates(),
+		Refresh: func() (interface{}, string, error) {
+			pollCtx, span := transport_tpg.StartSpan(ctx, "operation.poll", transport_tpg.Attr("operation", w.OpName()))
+			queryOp := w.QueryOp
+			if cw, ok := w.(ContextWaiter); ok {
+				queryOp = func() (interface{}, error) { return cw.QueryOpContext(pollCtx) }
+			}
+			op, state, err := commonRefreshFunc(w, queryOp)()
+			span.SetAttributes(transport_tpg.Attr("state", state))
+			span.End(err)
+			return op, state, err
+		},
 		Timeout:      timeout,
 		MinTimeout:   2 * time.Second,
 		PollInterval: pollInterval,
 	}
-	opRaw, err := c.WaitForState()
+	opRaw, err := c.WaitForStateContext(ctx)
 	if err != nil {
 		return fmt.Errorf("Error waiting for %s: %w", activity, err)
 	}
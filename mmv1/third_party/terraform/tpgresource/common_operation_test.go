@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"net/url"
 	"testing"
 	"time"
@@ -67,3 +68,47 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+// contextTestWaiter is a TestWaiter recording the span of the contexts its
+// operation is queried with.
+type contextTestWaiter struct {
+	TestWaiter
+	spans []interface{}
+}
+
+func (w *contextTestWaiter) QueryOpContext(ctx context.Context) (interface{}, error) {
+	w.spans = append(w.spans, ctx.Value(testSpanKey{}))
+	return w.TestWaiter.QueryOp()
+}
+
+type testSpanKey struct{}
+
+type testSpan struct{}
+
+func (testSpan) SetAttributes(...transport_tpg.SpanAttribute) {}
+func (testSpan) End(error)                                    {}
+
+// testTracer gives the context of each span the span name.
+type testTracer struct{}
+
+func (testTracer) StartSpan(ctx context.Context, name string, attrs ...transport_tpg.SpanAttribute) (context.Context, transport_tpg.Span) {
+	return context.WithValue(ctx, testSpanKey{}, name), testSpan{}
+}
+
+func TestOperationWaitContext_QueryOpContext(t *testing.T) {
+	transport_tpg.SetTracer(testTracer{})
+	t.Cleanup(func() { transport_tpg.SetTracer(nil) })
+
+	testWaiter := &contextTestWaiter{}
+	if err := OperationWaitContext(context.Background(), testWaiter, "my-activity", 1*time.Minute, 0*time.Second); err != nil {
+		t.Fatalf("unexpected error waiting for operation: got '%v', want 'nil'", err)
+	}
+	if len(testWaiter.spans) != 2 {
+		t.Fatalf("expected the operation to be queried 2 times with a context, instead was queried %v time(s)", len(testWaiter.spans))
+	}
+	for _, span := range testWaiter.spans {
+		if span != "operation.poll" {
+			t.Errorf("expected the operation to be queried with the context of its poll span, got %v", span)
+		}
+	}
+}
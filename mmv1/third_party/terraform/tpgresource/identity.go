@@ -0,0 +1,63 @@
+package tpgresource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// Sets the resource identity from the values of its attributes in the
+// resource data. The attributes are the fields of the resource id format.
+func SetIdentity(d *schema.ResourceData, attributes []string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error reading identity: %s", err)
+	}
+	for _, attribute := range attributes {
+		if err := identity.Set(attribute, d.Get(attribute)); err != nil {
+			return fmt.Errorf("Error setting %s in identity: %s", attribute, err)
+		}
+	}
+	return nil
+}
+
+// Sets the fields of a resource imported with an identity instead of an
+// import id, eg:
+//
+//	import {
+//	  to       = google_pubsub_topic.default
+//	  identity = { name = "my-topic" }
+//	}
+//
+// Project, region and zone default to the provider configuration when they
+// are not in the identity, like they do for import ids.
+func ParseImportIdentity(attributes []string, d *schema.ResourceData, config *transport_tpg.Config) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("Error reading identity: %s", err)
+	}
+	for _, attribute := range attributes {
+		v, ok := identity.GetOk(attribute)
+		if !ok {
+			switch attribute {
+			case "project":
+				v, err = GetProject(d, config)
+			case "region":
+				v, err = GetRegion(d, config)
+			case "zone":
+				v, err = GetZone(d, config)
+			default:
+				return fmt.Errorf("Import identity is missing %s", attribute)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := d.Set(attribute, v); err != nil {
+			return fmt.Errorf("Error setting %s: %s", attribute, err)
+		}
+	}
+	return nil
+}
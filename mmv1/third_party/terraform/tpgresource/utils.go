@@ -483,40 +483,77 @@ func ServiceAccountFQN(serviceAccount string, d TerraformResourceData, config *t
 	return fmt.Sprintf("projects/-/serviceAccounts/%s@%s.iam.gserviceaccount.com", serviceAccount, project), nil
 }
 
+// Lists every page of baseUrl. Responses carry the token of the next page in
+// nextPageToken, as described by AIP-158, or in pageToken for older APIs.
 func PaginatedListRequest(project, baseUrl, userAgent string, config *transport_tpg.Config, flattener func(map[string]interface{}) []interface{}) ([]interface{}, error) {
-	res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
-		Config:    config,
-		Method:    "GET",
-		Project:   project,
-		RawURL:    baseUrl,
-		UserAgent: userAgent,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	ls := flattener(res)
-	pageToken, ok := res["pageToken"]
-	for ok {
-		if pageToken.(string) == "" {
-			break
-		}
-		url := fmt.Sprintf("%s?pageToken=%s", baseUrl, pageToken.(string))
-		res, err = transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+	var ls []interface{}
+	pageUrl := baseUrl
+	for {
+		res, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
 			Config:    config,
 			Method:    "GET",
 			Project:   project,
-			RawURL:    url,
+			RawURL:    pageUrl,
 			UserAgent: userAgent,
 		})
 		if err != nil {
 			return nil, err
 		}
-		ls = append(ls, flattener(res))
-		pageToken, ok = res["pageToken"]
+		ls = append(ls, flattener(res)...)
+
+		pageToken, ok := res["nextPageToken"].(string)
+		if !ok {
+			pageToken, _ = res["pageToken"].(string)
+		}
+		if pageToken == "" {
+			return ls, nil
+		}
+		pageUrl, err = transport_tpg.AddQueryParams(baseUrl, map[string]string{"pageToken": pageToken})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Returns the items of a page of a list response. Aggregated lists group the
+// items by zone or region, along with a warning for locations without any.
+func ListResponseItems(res map[string]interface{}, key string, aggregated bool) []interface{} {
+	if !aggregated {
+		items, _ := res[key].([]interface{})
+		return items
+	}
+
+	var items []interface{}
+	locations, _ := res[key].(map[string]interface{})
+	for _, v := range locations {
+		location, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, locationItems := range location {
+			if k == "warning" {
+				continue
+			}
+			if l, ok := locationItems.([]interface{}); ok {
+				items = append(items, l...)
+			}
+		}
 	}
+	return items
+}
 
-	return ls, nil
+// Returns the value at a dot-separated path of a response, eg:
+// settings.settingsVersion, or nil if a key of the path is missing.
+func GetValueAtPath(res map[string]interface{}, path string) interface{} {
+	var v interface{} = res
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
 }
 
 func GetInterconnectAttachmentLink(config *transport_tpg.Config, project, region, ic, userAgent string) (string, error) {
@@ -1,8 +1,12 @@
 package tpgresource_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -1012,6 +1016,175 @@ func TestSnakeToPascalCase(t *testing.T) {
 	}
 }
 
+func TestGetValueAtPath(t *testing.T) {
+	res := map[string]interface{}{
+		"labelFingerprint": "abc",
+		"settings": map[string]interface{}{
+			"settingsVersion": "3",
+		},
+	}
+
+	cases := map[string]struct {
+		Path     string
+		Expected interface{}
+	}{
+		"top level": {
+			Path:     "labelFingerprint",
+			Expected: "abc",
+		},
+		"nested": {
+			Path:     "settings.settingsVersion",
+			Expected: "3",
+		},
+		"missing": {
+			Path:     "tags.fingerprint",
+			Expected: nil,
+		},
+		"not an object": {
+			Path:     "labelFingerprint.value",
+			Expected: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := tpgresource.GetValueAtPath(res, tc.Path); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s: expected %v, got %v", tn, tc.Expected, got)
+		}
+	}
+}
+
+func TestPaginatedListRequest(t *testing.T) {
+	cases := map[string]struct {
+		Pages          map[string]string
+		ExpectedItems  []interface{}
+		ExpectedTokens []string
+	}{
+		"nextPageToken": {
+			Pages: map[string]string{
+				"":  `{"items": ["a", "b"], "nextPageToken": "2"}`,
+				"2": `{"items": ["c"]}`,
+			},
+			ExpectedItems:  []interface{}{"a", "b", "c"},
+			ExpectedTokens: []string{"", "2"},
+		},
+		"pageToken": {
+			Pages: map[string]string{
+				"":  `{"items": ["a"], "pageToken": "2"}`,
+				"2": `{"items": ["b"], "pageToken": ""}`,
+			},
+			ExpectedItems:  []interface{}{"a", "b"},
+			ExpectedTokens: []string{"", "2"},
+		},
+		"empty page": {
+			Pages: map[string]string{
+				"":  `{"nextPageToken": "2"}`,
+				"2": `{"items": ["a"]}`,
+			},
+			ExpectedItems:  []interface{}{"a"},
+			ExpectedTokens: []string{"", "2"},
+		},
+		"empty list": {
+			Pages: map[string]string{
+				"": `{}`,
+			},
+			ExpectedItems:  nil,
+			ExpectedTokens: []string{""},
+		},
+	}
+
+	for tn, tc := range cases {
+		var tokens []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get("pageToken")
+			tokens = append(tokens, token)
+			if r.URL.Query().Get("filter") != "status=READY" {
+				t.Errorf("%s: expected the query of the base url to be kept, got %q", tn, r.URL.RawQuery)
+			}
+			page, ok := tc.Pages[token]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if _, err := w.Write([]byte(page)); err != nil {
+				t.Errorf("[ERROR] unable to write to response writer: %v", err)
+			}
+		}))
+
+		config := &transport_tpg.Config{Client: ts.Client()}
+		flattener := func(res map[string]interface{}) []interface{} {
+			return tpgresource.ListResponseItems(res, "items", false)
+		}
+		items, err := tpgresource.PaginatedListRequest("my-project", ts.URL+"/v1/things?filter=status%3DREADY", "test-agent", config, flattener)
+		ts.Close()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tn, err)
+			continue
+		}
+		if !reflect.DeepEqual(items, tc.ExpectedItems) {
+			t.Errorf("%s: expected items %v, got %v", tn, tc.ExpectedItems, items)
+		}
+		if !reflect.DeepEqual(tokens, tc.ExpectedTokens) {
+			t.Errorf("%s: expected page tokens %q, got %q", tn, tc.ExpectedTokens, tokens)
+		}
+	}
+}
+
+func TestListResponseItems(t *testing.T) {
+	cases := map[string]struct {
+		Response   string
+		Aggregated bool
+		Expected   []string
+	}{
+		"list": {
+			Response: `{"items": [{"name": "a"}, {"name": "b"}]}`,
+			Expected: []string{"a", "b"},
+		},
+		"empty list": {
+			Response: `{"kind": "compute#addressList"}`,
+			Expected: nil,
+		},
+		"aggregated list": {
+			Response: `{"items": {
+				"regions/us-central1": {"addresses": [{"name": "a"}, {"name": "b"}]},
+				"regions/us-east1": {"addresses": [{"name": "c"}]}
+			}}`,
+			Aggregated: true,
+			Expected:   []string{"a", "b", "c"},
+		},
+		"aggregated list with empty locations": {
+			Response: `{"items": {
+				"regions/us-central1": {"addresses": [{"name": "a"}]},
+				"regions/us-east1": {"warning": {"code": "NO_RESULTS_ON_PAGE", "message": "There are no results for scope 'regions/us-east1' on this page."}}
+			}}`,
+			Aggregated: true,
+			Expected:   []string{"a"},
+		},
+		"empty aggregated list": {
+			Response:   `{"kind": "compute#addressAggregatedList"}`,
+			Aggregated: true,
+			Expected:   nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		var res map[string]interface{}
+		if err := json.Unmarshal([]byte(tc.Response), &res); err != nil {
+			t.Fatalf("%s: unable to parse response: %s", tn, err)
+		}
+
+		var names []string
+		for _, item := range tpgresource.ListResponseItems(res, "items", tc.Aggregated) {
+			names = append(names, item.(map[string]interface{})["name"].(string))
+		}
+		// Aggregated lists are read in no particular order of locations.
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, tc.Expected) {
+			t.Errorf("%s: expected items %v, got %v", tn, tc.Expected, names)
+		}
+	}
+}
+
 func TestCheckGoogleIamPolicy(t *testing.T) {
 	cases := []struct {
 		valid bool
@@ -0,0 +1,171 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// requestBatchersMutex guards Config.requestBatchers.
+var requestBatchersMutex sync.Mutex
+
+// RequestBatcher returns the batcher of the generated resources of service,
+// eg: Compute, creating it on first use.
+func (c *Config) RequestBatcher(service string) *RequestBatcher {
+	requestBatchersMutex.Lock()
+	defer requestBatchersMutex.Unlock()
+
+	if c.requestBatchers == nil {
+		c.requestBatchers = make(map[string]*RequestBatcher)
+	}
+	if b, ok := c.requestBatchers[service]; ok {
+		return b
+	}
+	b := NewRequestBatcher(service, c.Context, c.BatchingConfig)
+	c.requestBatchers[service] = b
+	return b
+}
+
+// BatchedRequestOptions describes a request combined with the requests sent
+// at the same time with the same BatchKey into a single request to the batch
+// endpoint RawURL.
+type BatchedRequestOptions struct {
+	SendRequestOptions
+
+	// Service selects the batcher of the request, see Config.RequestBatcher.
+	Service string
+
+	// BatchKey groups the requests combined into a batch.
+	BatchKey string
+
+	// RequestField is the list field of the batch request body holding the
+	// Body of each combined request.
+	RequestField string
+
+	// ResponseField is the list field of the batch response holding the
+	// result of each combined request, in order. If empty, each request gets
+	// the batch response.
+	ResponseField string
+
+	// ErrorField is the field of a result in ResponseField holding the error
+	// of its request, if it failed.
+	ErrorField string
+
+	// StatusField is the list field of the batch response holding the status
+	// of each combined request, in order. Requests whose status has a
+	// non-zero code fail.
+	StatusField string
+
+	// DebugId identifies the request in logs and errors.
+	DebugId string
+}
+
+// SendBatchedRequest sends a request combined with others into a batch, see
+// BatchedRequestOptions, and returns its own result.
+func SendBatchedRequest(opt BatchedRequestOptions) (map[string]interface{}, error) {
+	if opt.Config == nil {
+		return nil, fmt.Errorf("config is nil for batched request to %s", opt.RawURL)
+	}
+
+	if opt.Timeout == 0 {
+		opt.Timeout = DefaultRequestTimeout
+	}
+
+	request := &BatchRequest{
+		ResourceName: opt.RawURL,
+		Body:         []interface{}{opt.Body},
+		CombineF:     combineBatchedRequestBodies,
+		SendF: func(rawURL string, body interface{}) (interface{}, error) {
+			sendOpt := opt.SendRequestOptions
+			sendOpt.RawURL = rawURL
+			sendOpt.Body = map[string]interface{}{opt.RequestField: body}
+			return SendRequest(sendOpt)
+		},
+		DebugId: opt.DebugId,
+	}
+	if opt.ResponseField != "" || opt.StatusField != "" {
+		request.SplitF = splitBatchedResponse(opt.ResponseField, opt.ErrorField, opt.StatusField)
+	}
+
+	res, err := opt.Config.RequestBatcher(opt.Service).SendRequestWithTimeout(opt.BatchKey, request, opt.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	result, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("provider error: expected batched request result to be an object, got %v with type %T", res, res)
+	}
+	return result, nil
+}
+
+func combineBatchedRequestBodies(currV interface{}, toAddV interface{}) (interface{}, error) {
+	curr, ok := currV.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("provider error in batch combiner: expected data to be type []interface{}, got %v with type %T", currV, currV)
+	}
+	toAdd, ok := toAddV.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("provider error in batch combiner: expected data to be type []interface{}, got %v with type %T", toAddV, toAddV)
+	}
+	return append(curr, toAdd...), nil
+}
+
+// splitBatchedResponse returns the result of each request from the list
+// responseField of the batch response, or the whole batch response if empty,
+// failing the requests whose result has a non-empty errorField or whose status
+// in the list statusField has a non-zero code.
+func splitBatchedResponse(responseField, errorField, statusField string) BatcherSplitFunc {
+	return func(resp interface{}, count int) ([]interface{}, []error, error) {
+		res, ok := resp.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("expected batch response to be an object, got %v with type %T", resp, resp)
+		}
+		items := make([]interface{}, count)
+		if responseField == "" {
+			for i := range items {
+				items[i] = res
+			}
+		} else {
+			items, ok = res[responseField].([]interface{})
+			if !ok || len(items) != count {
+				return nil, nil, fmt.Errorf("expected %d results in field %q of batch response, got %v", count, responseField, res[responseField])
+			}
+		}
+		var statuses []interface{}
+		if statusField != "" {
+			statuses, ok = res[statusField].([]interface{})
+			if !ok || len(statuses) != count {
+				return nil, nil, fmt.Errorf("expected %d statuses in field %q of batch response, got %v", count, statusField, res[statusField])
+			}
+		}
+
+		errs := make([]error, count)
+		for i, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok && errorField != "" {
+				if itemErr, ok := itemObj[errorField]; ok && itemErr != nil {
+					errs[i] = batchedRequestError(i, itemErr)
+				}
+			}
+			if statusField == "" {
+				continue
+			}
+			if status, ok := statuses[i].(map[string]interface{}); ok {
+				if code, _ := status["code"].(float64); code != 0 {
+					errs[i] = batchedRequestError(i, status)
+				}
+			}
+		}
+		return items, errs, nil
+	}
+}
+
+func batchedRequestError(i int, itemErr interface{}) error {
+	errJson, err := json.Marshal(itemErr)
+	if err != nil {
+		errJson = []byte(fmt.Sprintf("%v", itemErr))
+	}
+	return fmt.Errorf("request %d of batch failed: %s", i, errJson)
+}
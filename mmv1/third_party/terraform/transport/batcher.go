@@ -50,6 +50,12 @@ type (
 		// Bodies.
 		SendF BatcherSendFunc
 
+		// SplitF function optionally determines the response and error of each
+		// request combined into a batch from the batch response, so that one
+		// failed request doesn't fail the others. If nil, every request gets
+		// the batch response.
+		SplitF BatcherSplitFunc
+
 		// ID for debugging request. This should be specific to a single request
 		// (i.e. per Terraform resource)
 		DebugId string
@@ -60,6 +66,11 @@ type (
 
 	// BatcherSendFunc is a function type for sending a batch request
 	BatcherSendFunc func(resourceName string, body interface{}) (interface{}, error)
+
+	// BatcherSplitFunc is a function type for splitting the response of a batch
+	// request into the responses and errors of the count requests combined
+	// into it, in the order they were combined.
+	BatcherSplitFunc func(resp interface{}, count int) ([]interface{}, []error, error)
 )
 
 // batchResponse bundles an API response (data, error) tuple.
@@ -163,7 +174,8 @@ func (b *RequestBatcher) SendRequestWithTimeout(batchKey string, request *BatchR
 	}
 	if !b.EnableBatching {
 		log.Printf("[DEBUG] Batching is disabled, sending single request for %q", request.DebugId)
-		return request.SendF(request.ResourceName, request.Body)
+		resp := request.splitResponse(request.send(), 1)[0]
+		return resp.body, resp.err
 	}
 
 	respCh, err := b.registerBatchRequest(batchKey, request)
@@ -236,6 +248,7 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 			Body:         newRequest.Body,
 			CombineF:     newRequest.CombineF,
 			SendF:        newRequest.SendF,
+			SplitF:       newRequest.SplitF,
 			DebugId:      fmt.Sprintf("Combined batch for started batch %q", batchKey),
 		},
 		batchKey:    batchKey,
@@ -247,7 +260,7 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 		batch := b.popBatch(batchKey)
 		if batch == nil {
 			log.Printf("[ERROR] batch should have been added to saved batches - just run as single request %q", newRequest.DebugId)
-			respCh <- newRequest.send()
+			respCh <- newRequest.splitResponse(newRequest.send(), 1)[0]
 			close(respCh)
 		} else {
 			b.sendBatchWithSingleRetry(batchKey, batch)
@@ -267,7 +280,7 @@ func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *starte
 		log.Printf("[DEBUG] Sending each request in batch separately")
 		for _, sub := range batch.subscribers {
 			log.Printf("[DEBUG] Retrying single request %q", sub.singleRequest.DebugId)
-			singleResp := sub.singleRequest.send()
+			singleResp := sub.singleRequest.splitResponse(sub.singleRequest.send(), 1)[0]
 			log.Printf("[DEBUG] Retried single request %q returned response: %v", sub.singleRequest.DebugId, singleResp)
 
 			if singleResp.IsError() {
@@ -280,8 +293,9 @@ func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *starte
 		}
 	} else {
 		// Send result to all subscribers
-		for _, sub := range batch.subscribers {
-			sub.respCh <- resp
+		resps := batch.splitResponse(resp, len(batch.subscribers))
+		for i, sub := range batch.subscribers {
+			sub.respCh <- resps[i]
 			close(sub.respCh)
 		}
 	}
@@ -334,3 +348,28 @@ func (req *BatchRequest) send() batchResponse {
 	v, err := req.SendF(req.ResourceName, req.Body)
 	return batchResponse{v, err}
 }
+
+// splitResponse returns the response of each of the count requests combined
+// into a batch request from the batch response, using SplitF if set.
+func (req *BatchRequest) splitResponse(resp batchResponse, count int) []batchResponse {
+	resps := make([]batchResponse, count)
+	if resp.IsError() || req.SplitF == nil {
+		for i := range resps {
+			resps[i] = resp
+		}
+		return resps
+	}
+
+	bodies, errs, err := req.SplitF(resp.body, count)
+	if err == nil && (len(bodies) != count || len(errs) != count) {
+		err = fmt.Errorf("provider error: expected %d responses splitting batch response, got %d bodies and %d errors", count, len(bodies), len(errs))
+	}
+	for i := range resps {
+		if err != nil {
+			resps[i] = batchResponse{err: err}
+		} else {
+			resps[i] = batchResponse{bodies[i], errs[i]}
+		}
+	}
+	return resps
+}
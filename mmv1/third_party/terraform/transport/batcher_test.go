@@ -153,7 +153,7 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 		log.Printf("[DEBUG] sendBatch body: %+v", body)
 		for _, v := range body.([]int) {
 			if v == failIdx {
-				return nil, fmt.Errorf(expectedErrMsg)
+				return nil, errors.New(expectedErrMsg)
 			}
 		}
 		return nil, nil
@@ -200,6 +200,142 @@ func TestRequestBatcher_errInSend(t *testing.T) {
 	wg.Wait()
 }
 
+func TestRequestBatcher_errInSplitResponse(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(1) * time.Second,
+			EnableBatching: true,
+		})
+
+	testCombine := func(body interface{}, toAdd interface{}) (interface{}, error) {
+		return append(body.([]int), toAdd.([]int)...), nil
+	}
+
+	sends := 0
+	testSendBatch := func(resourceName string, body interface{}) (interface{}, error) {
+		sends++
+		return body, nil
+	}
+
+	// The request with index 0 fails, the others get their own index back.
+	failIdx := 0
+	expectedErrMsg := fmt.Sprintf("Error - request with idx %d failed", failIdx)
+	testSplit := func(resp interface{}, count int) ([]interface{}, []error, error) {
+		var bodies []interface{}
+		var errs []error
+		for _, v := range resp.([]int) {
+			bodies = append(bodies, v)
+			if v == failIdx {
+				errs = append(errs, errors.New(expectedErrMsg))
+			} else {
+				errs = append(errs, nil)
+			}
+		}
+		return bodies, errs, nil
+	}
+
+	numRequests := 3
+
+	wg := sync.WaitGroup{}
+	wg.Add(numRequests)
+
+	for i := 0; i < numRequests; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			req := &BatchRequest{
+				DebugId:      fmt.Sprintf("splitError %d", idx),
+				ResourceName: "RESOURCE-SPLIT-ERROR",
+				Body:         []int{idx},
+				CombineF:     testCombine,
+				SendF:        testSendBatch,
+				SplitF:       testSplit,
+			}
+
+			resp, err := testBatcher.SendRequestWithTimeout("batchSplitError", req, time.Duration(10)*time.Second)
+			if idx == failIdx {
+				if err == nil || !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Errorf("expected error %q for request %d, got %v", expectedErrMsg, idx, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected request %d to succeed, got error: %v", idx, err)
+			}
+			if resp != idx {
+				t.Errorf("expected request %d to get its own response, got %v", idx, resp)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	if sends != 1 {
+		t.Errorf("expected a single batch request, got %d", sends)
+	}
+}
+
+func TestSplitBatchedResponse(t *testing.T) {
+	split := splitBatchedResponse("results", "error", "")
+
+	bodies, errs, err := split(map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"error": map[string]interface{}{"code": 400.0, "message": "invalid"}},
+		},
+	}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0].(map[string]interface{})["name"] != "a" {
+		t.Errorf("unexpected results %v", bodies)
+	}
+	if errs[0] != nil {
+		t.Errorf("expected first request to succeed, got error: %v", errs[0])
+	}
+	if errs[1] == nil || !strings.Contains(errs[1].Error(), "invalid") {
+		t.Errorf("expected second request to fail, got error: %v", errs[1])
+	}
+
+	if _, _, err := split(map[string]interface{}{"results": []interface{}{}}, 2); err == nil {
+		t.Errorf("expected an error for a missing result")
+	}
+}
+
+func TestSplitBatchedResponse_statusField(t *testing.T) {
+	split := splitBatchedResponse("writeResults", "", "status")
+
+	bodies, errs, err := split(map[string]interface{}{
+		"writeResults": []interface{}{
+			map[string]interface{}{"updateTime": "2026-01-01T00:00:00Z"},
+			map[string]interface{}{},
+		},
+		"status": []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"code": 6.0, "message": "already exists"},
+		},
+	}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0].(map[string]interface{})["updateTime"] != "2026-01-01T00:00:00Z" {
+		t.Errorf("unexpected results %v", bodies)
+	}
+	if errs[0] != nil {
+		t.Errorf("expected first request to succeed, got error: %v", errs[0])
+	}
+	if errs[1] == nil || !strings.Contains(errs[1].Error(), "already exists") {
+		t.Errorf("expected second request to fail, got error: %v", errs[1])
+	}
+
+	if _, _, err := split(map[string]interface{}{
+		"writeResults": []interface{}{map[string]interface{}{}, map[string]interface{}{}},
+	}, 2); err == nil {
+		t.Errorf("expected an error for missing statuses")
+	}
+}
+
 func TestRequestBatcher_errTimeout(t *testing.T) {
 	testBatcher := NewRequestBatcher(
 		"testBatcher",
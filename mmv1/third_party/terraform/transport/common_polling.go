@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sync"
@@ -33,25 +34,49 @@ func SuccessPollResult() PollResult {
 }
 
 func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
+	timeout time.Duration, targetOccurrences int) error {
+	return PollingWaitTimeContext(context.Background(), pollF, checkResponse, activity, timeout, targetOccurrences)
+}
+
+// PollingWaitTimeContext is PollingWaitTime, cancelled with ctx and traced
+// under the span of ctx.
+func PollingWaitTimeContext(ctx context.Context, pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
 	timeout time.Duration, targetOccurrences int) error {
 	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
 	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
+	ctx, span := StartSpan(ctx, "polling.wait", Attr("activity", activity), Attr("target_occurrences", targetOccurrences))
+	poll := func() *retry.RetryError {
+		_, pollSpan := StartSpan(ctx, "polling.poll", Attr("activity", activity))
+		readResp, readErr := pollF()
+		result := checkResponse(readResp, readErr)
+		if result != nil {
+			pollSpan.SetAttributes(Attr("retryable", result.Retryable))
+			pollSpan.End(result.Err)
+		} else {
+			pollSpan.End(nil)
+		}
+		return result
+	}
+
+	var err error
 	if targetOccurrences == 1 {
-		return retry.Retry(timeout, func() *retry.RetryError {
-			readResp, readErr := pollF()
-			return checkResponse(readResp, readErr)
-		})
+		err = retry.RetryContext(ctx, timeout, poll)
+	} else {
+		err = retryWithTargetOccurrences(ctx, timeout, targetOccurrences, poll)
 	}
-	return RetryWithTargetOccurrences(timeout, targetOccurrences, func() *retry.RetryError {
-		readResp, readErr := pollF()
-		return checkResponse(readResp, readErr)
-	})
+	span.End(err)
+	return err
 }
 
 // RetryWithTargetOccurrences is a basic wrapper around StateChangeConf that will retry
 // a function until it returns the specified amount of target occurrences continuously.
 // Adapted from the Retry function in the go SDK.
 func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
+	f retry.RetryFunc) error {
+	return retryWithTargetOccurrences(context.Background(), timeout, targetOccurrences, f)
+}
+
+func retryWithTargetOccurrences(ctx context.Context, timeout time.Duration, targetOccurrences int,
 	f retry.RetryFunc) error {
 	// These are used to pull the error out of the function; need a mutex to
 	// avoid a data race.
@@ -84,7 +109,7 @@ func RetryWithTargetOccurrences(timeout time.Duration, targetOccurrences int,
 		},
 	}
 
-	_, waitErr := c.WaitForState()
+	_, waitErr := c.WaitForStateContext(ctx)
 
 	// Need to acquire the lock here to be able to avoid race using resultErr as
 	// the return value
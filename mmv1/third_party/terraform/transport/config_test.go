@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -469,6 +470,94 @@ func TestConfigLoadAndValidate_customBatchingConfig(t *testing.T) {
 	}
 }
 
+func TestExpandProviderRetryPolicy(t *testing.T) {
+	policy, err := transport_tpg.ExpandProviderRetryPolicy(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy != nil {
+		t.Fatalf("expected no retry policy when the block is not set, got %v", policy)
+	}
+
+	policy, err = transport_tpg.ExpandProviderRetryPolicy([]interface{}{
+		map[string]interface{}{
+			"initial_backoff":    "1s",
+			"max_backoff":        "30s",
+			"multiplier":         2.0,
+			"jitter":             0.2,
+			"max_attempts":       5,
+			"honor_server_hints": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &transport_tpg.RetryPolicy{
+		InitialBackoff:   time.Second,
+		MaxBackoff:       30 * time.Second,
+		Multiplier:       2,
+		Jitter:           0.2,
+		MaxAttempts:      5,
+		HonorServerHints: true,
+	}
+	if !reflect.DeepEqual(policy, expected) {
+		t.Fatalf("expected retry policy %v, got %v", expected, policy)
+	}
+
+	for _, invalid := range []map[string]interface{}{
+		{"initial_backoff": "invalid value"},
+		{"multiplier": 0.5},
+		{"jitter": 1.5},
+		{"max_attempts": -1},
+	} {
+		if _, err := transport_tpg.ExpandProviderRetryPolicy([]interface{}{invalid}); err == nil {
+			t.Errorf("expected an error for %v", invalid)
+		}
+	}
+}
+
+func TestConfigLoadAndValidate_rateLimits(t *testing.T) {
+	config := &transport_tpg.Config{
+		Credentials:      transport_tpg.TestFakeCredentialsPath,
+		Project:          "my-gce-project",
+		Region:           "us-central1",
+		ComputeBasePath:  "https://compute.googleapis.com/compute/v1/",
+		CloudRunBasePath: "https://{{location}}-run.googleapis.com/",
+		RateLimits: map[string]float64{
+			"compute":            20,
+			"dns.googleapis.com": 5,
+			"cloud_run":          5,
+		},
+	}
+	if err := config.LoadAndValidate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config.RateLimits = map[string]float64{"not_a_product": 20}
+	if err := config.LoadAndValidate(context.Background()); err == nil {
+		t.Fatalf("expected an error for an unknown product")
+	}
+
+	config.RateLimits = map[string]float64{"compute": 0}
+	if err := config.LoadAndValidate(context.Background()); err == nil {
+		t.Fatalf("expected an error for a rate limit of 0")
+	}
+}
+
+func TestConfigLoadAndValidate_logProducts(t *testing.T) {
+	config := &transport_tpg.Config{
+		Credentials:      transport_tpg.TestFakeCredentialsPath,
+		Project:          "my-gce-project",
+		Region:           "us-central1",
+		ComputeBasePath:  "https://compute.googleapis.com/compute/v1/",
+		CloudRunBasePath: "https://{{location}}-run.googleapis.com/",
+		LogProducts:      []string{"compute", "dns.googleapis.com", "cloud_run", "not_a_product"},
+	}
+	if err := config.LoadAndValidate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestRemoveBasePathVersion(t *testing.T) {
 	cases := []struct {
 		BaseURL  string
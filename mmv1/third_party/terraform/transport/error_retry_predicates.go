@@ -228,6 +228,52 @@ func MetadataRetryWrapper(update func() error) error {
 	return fmt.Errorf("Failed to update metadata after %d retries", attempt)
 }
 
+// Retry the request if it was rejected because the etag it sent is stale,
+// with an ABORTED (409) or FAILED_PRECONDITION (412) error.
+// See https://google.aip.dev/154
+func IsEtagConflictError(err error) (bool, string) {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false, ""
+	}
+
+	if gerr.Code == 409 || gerr.Code == 412 {
+		return true, "etag mismatch"
+	}
+
+	return false, ""
+}
+
+// APIs using etags for optimistic concurrency reject requests sent with a
+// stale etag. Calls send with etag, and calls it again with the etag returned
+// by readEtag up to retries times while it fails with an etag mismatch.
+func RetryWithEtag(etag string, readEtag func() (string, error), send func(etag string) error, retries int, timeout time.Duration) error {
+	attempt := 0
+	return Retry(RetryOptions{
+		RetryFunc: func() error {
+			if attempt > 0 {
+				var err error
+				etag, err = readEtag()
+				if err != nil {
+					return err
+				}
+			}
+			attempt++
+			return send(etag)
+		},
+		Timeout:              timeout,
+		ErrorRetryPredicates: []RetryErrorPredicateFunc{IsEtagConflictError},
+		ErrorAbortPredicates: []RetryErrorPredicateFunc{
+			func(_ error) (bool, string) {
+				if attempt > retries {
+					return true, fmt.Sprintf("etag mismatch after %d retries", retries)
+				}
+				return false, ""
+			},
+		},
+	})
+}
+
 // If a permission necessary to provision a resource is created in the same config
 // as the resource itself, the permission may not have propagated by the time terraform
 // attempts to create the resource. This allows those errors to be retried until the timeout expires
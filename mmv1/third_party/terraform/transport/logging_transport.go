@@ -0,0 +1,322 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// LogProductsEnvVar limits request logging to a comma-separated list of
+// products, eg: compute, or hosts, eg: compute.googleapis.com.
+const LogProductsEnvVar = "GOOGLE_TERRAFORM_LOG_PRODUCTS"
+
+const redacted = "REDACTED"
+
+// redactedHeaders are the request headers whose values are never logged.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"X-Goog-Api-Key",
+}
+
+// redactedQueryParams are the query parameters whose values are never logged.
+var redactedQueryParams = []string{
+	"access_token",
+	"key",
+}
+
+// secretFields are the normalized names, see normalizeFieldName, of fields
+// whose values are never logged, in addition to the Sensitive and WriteOnly
+// fields of resource schemas.
+var secretFields = map[string]bool{
+	"accesstoken":       true,
+	"apikey":            true,
+	"assertion":         true,
+	"clientsecret":      true,
+	"idtoken":           true,
+	"keystring":         true,
+	"password":          true,
+	"privatekey":        true,
+	"privatekeydata":    true,
+	"refreshtoken":      true,
+	"serviceaccountkey": true,
+	"sharedsecret":      true,
+}
+
+var (
+	sensitiveFieldsMu sync.RWMutex
+	sensitiveFields   = make(map[string]bool)
+	// sensitiveApiFields are the normalized dot notation API paths of
+	// sensitive fields, eg: payload.data
+	sensitiveApiFields = make(map[string]bool)
+)
+
+// RegisterSensitiveFields redacts the fields flagged Sensitive or WriteOnly in
+// the schemas of resources from the logged request and response bodies.
+// Fields are matched by name regardless of case and underscores, so that
+// a schema field such as private_key also matches the API field privateKey.
+func RegisterSensitiveFields(resources map[string]*schema.Resource) {
+	sensitiveFieldsMu.Lock()
+	defer sensitiveFieldsMu.Unlock()
+	for _, r := range resources {
+		registerSensitiveSchemaFields(r.SchemaMap())
+	}
+}
+
+func registerSensitiveSchemaFields(schemaMap map[string]*schema.Schema) {
+	for name, s := range schemaMap {
+		if s.Sensitive || s.WriteOnly {
+			// Write-only fields are named after the API field they set, eg:
+			// password_wo sets password.
+			sensitiveFields[normalizeFieldName(strings.TrimSuffix(name, "_wo"))] = true
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			registerSensitiveSchemaFields(elem.SchemaMap())
+		}
+	}
+}
+
+// RegisterSensitiveApiFields redacts the fields at the dot notation API paths,
+// eg: payload.data, from the logged request and response bodies. A path
+// matches wherever it's nested in a body, as requests and responses may wrap
+// the resource, and list items aren't part of it.
+func RegisterSensitiveApiFields(paths []string) {
+	sensitiveFieldsMu.Lock()
+	defer sensitiveFieldsMu.Unlock()
+	for _, path := range paths {
+		sensitiveApiFields[normalizeFieldName(path)] = true
+	}
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// isSecretField returns whether the field at path, the normalized names of the
+// field and the objects it's nested in, is secret.
+func isSecretField(path []string) bool {
+	name := path[len(path)-1]
+	if secretFields[name] {
+		return true
+	}
+	sensitiveFieldsMu.RLock()
+	defer sensitiveFieldsMu.RUnlock()
+	if sensitiveFields[name] {
+		return true
+	}
+	for i := range path {
+		if sensitiveApiFields[strings.Join(path[i:], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+// requestAttemptContextKey holds the attempt number of a request retried by
+// retryTransport in the request context.
+type requestAttemptContextKey struct{}
+
+// A http.RoundTripper that logs each request and its response as a JSON entry
+// when TF_LOG is DEBUG or TRACE. Credentials and secret fields are redacted
+// from the entries so that logs can be shared in bug reports.
+type loggingTransport struct {
+	// hosts limits logging to the requests sent to them. All requests are
+	// logged if empty.
+	hosts    map[string]bool
+	internal http.RoundTripper
+}
+
+// requestLogEntry is the JSON entry logged for a request.
+type requestLogEntry struct {
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	Attempt        int               `json:"attempt,omitempty"`
+	Status         int               `json:"status,omitempty"`
+	LatencyMs      int64             `json:"latency_ms"`
+	Error          string            `json:"error,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    interface{}       `json:"request_body,omitempty"`
+	ResponseBody   interface{}       `json:"response_body,omitempty"`
+}
+
+// NewTransportWithStructuredLogging constructs a loggingTransport logging the
+// requests sent to hosts, which may be patterns of regional hosts as in
+// NewTransportWithRateLimits, or all requests if hosts is empty.
+func NewTransportWithStructuredLogging(t http.RoundTripper, hosts map[string]bool) *loggingTransport {
+	return &loggingTransport{
+		hosts:    hosts,
+		internal: t,
+	}
+}
+
+// logsHost returns whether the requests sent to host are logged.
+func (t *loggingTransport) logsHost(host string) bool {
+	logged, ok := matchHost(t.hosts, host)
+	return ok && logged
+}
+
+// RoundTrip implements the RoundTripper interface method.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !logging.IsDebugOrHigher() || (len(t.hosts) > 0 && !t.logsHost(req.URL.Host)) {
+		return t.internal.RoundTrip(req)
+	}
+
+	entry := requestLogEntry{
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if attempt, ok := req.Context().Value(requestAttemptContextKey{}).(int); ok {
+		entry.Attempt = attempt
+	}
+	if body, err := readRequestBody(req); err != nil {
+		entry.RequestBody = fmt.Sprintf("unable to read body: %s", err)
+	} else {
+		entry.RequestBody = redactBody(body, req.Header.Get("Content-Type"))
+	}
+
+	start := time.Now()
+	resp, err := t.internal.RoundTrip(req)
+	entry.LatencyMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		if body, err := readResponseBody(resp); err != nil {
+			entry.ResponseBody = fmt.Sprintf("unable to read body: %s", err)
+		} else {
+			entry.ResponseBody = redactBody(body, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	line, jsonErr := json.Marshal(entry)
+	if jsonErr != nil {
+		log.Printf("[WARN] Unable to encode log entry for request to %s: %s", entry.URL, jsonErr)
+	} else {
+		log.Printf("[DEBUG] Google API Request: %s", line)
+	}
+	return resp, err
+}
+
+// readRequestBody returns the body of req, leaving it readable.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// readResponseBody returns the body of resp, leaving it readable.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for _, param := range redactedQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+			redactedURL.RawQuery = query.Encode()
+		}
+	}
+	return redactedURL.String()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	headers := make(map[string]string)
+	for k, v := range header {
+		headers[k] = strings.Join(v, ", ")
+	}
+	for _, k := range redactedHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(k)]; ok {
+			headers[http.CanonicalHeaderKey(k)] = redacted
+		}
+	}
+	return headers
+}
+
+// redactBody returns a body to log, with the values of secret fields redacted.
+// JSON and form bodies are logged as objects, other bodies only by size as
+// they can't be redacted.
+func redactBody(body []byte, contentType string) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			form := make(map[string]interface{})
+			for k, v := range values {
+				form[k] = strings.Join(v, ", ")
+			}
+			return redactValue(form, nil)
+		}
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("%d bytes of %q", len(body), contentType)
+	}
+	return redactValue(v, nil)
+}
+
+// redactValue redacts the secret fields of v, nested at path.
+func redactValue(v interface{}, path []string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			fieldPath := append(slices.Clip(path), normalizeFieldName(k))
+			if isSecretField(fieldPath) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValue(field, fieldPath)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item, path)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,226 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// captureRequestLogs returns the log entries of the requests logged while f
+// runs.
+func captureRequestLogs(t *testing.T, f func()) []map[string]interface{} {
+	t.Setenv("TF_LOG", "DEBUG")
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	f()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		_, entryJson, ok := strings.Cut(line, "[DEBUG] Google API Request: ")
+		if !ok {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(entryJson), &entry); err != nil {
+			t.Fatalf("expected a JSON log entry, got %q: %s", entryJson, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLoggingTransport_Redaction(t *testing.T) {
+	RegisterSensitiveFields(map[string]*schema.Resource{
+		"google_test_resource": {
+			Schema: map[string]*schema.Schema{
+				"nested": {
+					Type: schema.TypeList,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"secret_value": {Type: schema.TypeString, Sensitive: true},
+						},
+					},
+				},
+				"token_wo": {Type: schema.TypeString, WriteOnly: true},
+			},
+		},
+	})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || !strings.Contains(string(body), "hunter2") {
+			t.Errorf("expected the unredacted body to be sent, got %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"name": "foo", "privateKeyData": "c2VjcmV0"}`)); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+	client := &http.Client{Transport: NewTransportWithStructuredLogging(http.DefaultTransport, nil)}
+
+	entries := captureRequestLogs(t, func() {
+		body := `{"name": "foo", "password": "hunter2", "nested": [{"secretValue": "bar"}], "token": "baz"}`
+		req, err := http.NewRequest("POST", ts.URL+"/v1/foo?key=abc&view=FULL", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("unable to construct request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer abc")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil || !strings.Contains(string(respBody), "c2VjcmV0") {
+			t.Errorf("expected the unredacted response to be returned, got %q", respBody)
+		}
+	})
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["method"] != "POST" || entry["status"] != float64(200) {
+		t.Errorf("expected method and status in log entry, got %v", entry)
+	}
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Errorf("expected latency in log entry, got %v", entry)
+	}
+	u, err := url.Parse(entry["url"].(string))
+	if err != nil || u.Query().Get("key") != redacted || u.Query().Get("view") != "FULL" {
+		t.Errorf("expected key query parameter to be redacted, got %v", entry["url"])
+	}
+	if headers := entry["request_headers"].(map[string]interface{}); headers["Authorization"] != redacted {
+		t.Errorf("expected Authorization header to be redacted, got %v", headers)
+	}
+
+	expectedRequestBody := map[string]interface{}{
+		"name":     "foo",
+		"password": redacted,
+		"nested":   []interface{}{map[string]interface{}{"secretValue": redacted}},
+		"token":    redacted,
+	}
+	if got, _ := json.Marshal(entry["request_body"]); string(got) != mustMarshal(t, expectedRequestBody) {
+		t.Errorf("expected request body %s, got %s", mustMarshal(t, expectedRequestBody), got)
+	}
+	expectedResponseBody := map[string]interface{}{"name": "foo", "privateKeyData": redacted}
+	if got, _ := json.Marshal(entry["response_body"]); string(got) != mustMarshal(t, expectedResponseBody) {
+		t.Errorf("expected response body %s, got %s", mustMarshal(t, expectedResponseBody), got)
+	}
+}
+
+func TestLoggingTransport_Hosts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse test server url: %v", err)
+	}
+
+	for _, tc := range []struct {
+		hosts    map[string]bool
+		expected int
+	}{
+		{nil, 1},
+		{map[string]bool{u.Host: true}, 1},
+		{map[string]bool{"compute.googleapis.com": true}, 0},
+	} {
+		client := &http.Client{Transport: NewTransportWithStructuredLogging(http.DefaultTransport, tc.hosts)}
+		entries := captureRequestLogs(t, func() {
+			resp, err := client.Get(ts.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+		})
+		if len(entries) != tc.expected {
+			t.Errorf("expected %d log entries for hosts %v, got %d", tc.expected, tc.hosts, len(entries))
+		}
+	}
+}
+
+func TestLoggingTransport_Attempts(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			if _, err := w.Write([]byte("Code: 500")); err != nil {
+				t.Errorf("[ERROR] unable to write to response writer: %v", err)
+			}
+			return
+		}
+		w.WriteHeader(testRetryTransportCodeSuccess)
+	}))
+	defer ts.Close()
+	client := &http.Client{
+		Transport: &retryTransport{
+			internal:        NewTransportWithStructuredLogging(http.DefaultTransport, nil),
+			retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+			retryPolicy:     &RetryPolicy{InitialBackoff: time.Millisecond * 10},
+		},
+	}
+
+	entries := captureRequestLogs(t, func() {
+		resp, err := client.Get(ts.URL)
+		testRetryTransport_checkSuccess(t, resp, err)
+	})
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if entry["attempt"] != float64(i+1) {
+			t.Errorf("expected attempt %d, got %v", i+1, entry["attempt"])
+		}
+	}
+	if entries[0]["status"] != float64(testRetryTransportCodeRetry) || entries[1]["status"] != float64(testRetryTransportCodeSuccess) {
+		t.Errorf("expected a failed then a successful attempt, got %v and %v", entries[0], entries[1])
+	}
+}
+
+func TestRedactBody_apiFields(t *testing.T) {
+	RegisterSensitiveApiFields([]string{"test_payload.test_data"})
+
+	body := `{"name": "foo", "testData": "bar", "testPayload": {"testData": "c2VjcmV0"}, "response": {"testPayload": {"testData": "c2VjcmV0"}}}`
+	got := mustMarshal(t, redactBody([]byte(body), "application/json"))
+	expected := `{"name":"foo","response":{"testPayload":{"testData":"REDACTED"}},"testData":"bar","testPayload":{"testData":"REDACTED"}}`
+	if got != expected {
+		t.Errorf("expected body %s, got %s", expected, got)
+	}
+}
+
+func TestRedactBody_nonJson(t *testing.T) {
+	form := redactBody([]byte("grant_type=refresh&refresh_token=abc"), "application/x-www-form-urlencoded")
+	if got := mustMarshal(t, form); got != `{"grant_type":"refresh","refresh_token":"REDACTED"}` {
+		t.Errorf("expected redacted form body, got %s", got)
+	}
+
+	if got := redactBody([]byte("binary data"), "application/octet-stream"); got != `11 bytes of "application/octet-stream"` {
+		t.Errorf("expected body to be logged by size, got %v", got)
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unable to marshal %v: %s", v, err)
+	}
+	return string(b)
+}
@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"log"
 	"sync"
 )
@@ -19,8 +20,15 @@ type MutexKV struct {
 // Locks the mutex for the given key. Caller is responsible for calling Unlock
 // for the same key
 func (m *MutexKV) Lock(key string) {
+	m.LockContext(context.Background(), key)
+}
+
+// LockContext is Lock, tracing the wait under the span of ctx.
+func (m *MutexKV) LockContext(ctx context.Context, key string) {
 	log.Printf("[DEBUG] Locking %q", key)
+	_, span := StartSpan(ctx, "mutex.wait", Attr("key", key), Attr("mode", "write"))
 	m.get(key).Lock()
+	span.End(nil)
 	log.Printf("[DEBUG] Locked %q", key)
 }
 
@@ -34,8 +42,15 @@ func (m *MutexKV) Unlock(key string) {
 // Acquires a read-lock on the mutex for the given key. Caller is responsible for calling RUnlock
 // for the same key
 func (m *MutexKV) RLock(key string) {
+	m.RLockContext(context.Background(), key)
+}
+
+// RLockContext is RLock, tracing the wait under the span of ctx.
+func (m *MutexKV) RLockContext(ctx context.Context, key string) {
 	log.Printf("[DEBUG] RLocking %q", key)
+	_, span := StartSpan(ctx, "mutex.wait", Attr("key", key), Attr("mode", "read"))
 	m.get(key).RLock()
+	span.End(nil)
 	log.Printf("[DEBUG] RLocked %q", key)
 }
 
@@ -69,7 +84,13 @@ func NewMutexKV() *MutexKV {
 var MutexStore = NewMutexKV()
 
 func LockedCall(lockKey string, f func() error) error {
-	MutexStore.Lock(lockKey)
+	return LockedCallContext(context.Background(), lockKey, f)
+}
+
+// LockedCallContext is LockedCall, tracing the wait for the lock under the
+// span of ctx.
+func LockedCallContext(ctx context.Context, lockKey string, f func() error) error {
+	MutexStore.LockContext(ctx, lockKey)
 	defer MutexStore.Unlock(lockKey)
 
 	return f()
@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// A http.RoundTripper that limits the rate of requests sent to each host with
+// a token bucket, so that large configurations don't exhaust per minute
+// quotas and rely on retries.
+type rateLimitTransport struct {
+	limiters map[string]*rate.Limiter
+	internal http.RoundTripper
+}
+
+// NewTransportWithRateLimits constructs a rateLimitTransport sending at most
+// limits[host] requests per second to each host of limits. Hosts may be
+// patterns of regional hosts, eg: {{location}}-run.googleapis.com, whose
+// locations share a limit. Requests to other hosts are not limited.
+func NewTransportWithRateLimits(t http.RoundTripper, limits map[string]float64) *rateLimitTransport {
+	limiters := make(map[string]*rate.Limiter)
+	for host, limit := range limits {
+		// Allow bursts of up to one second of requests.
+		limiters[host] = rate.NewLimiter(rate.Limit(limit), int(math.Max(1, math.Ceil(limit))))
+	}
+	return &rateLimitTransport{
+		limiters: limiters,
+		internal: t,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method.
+// It waits for the limiter of the request host, if any, before sending it.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter, ok := matchHost(t.limiters, req.URL.Host)
+	if !ok {
+		return t.internal.RoundTrip(req)
+	}
+
+	r := limiter.Reserve()
+	if delay := r.Delay(); delay > 0 {
+		log.Printf("[DEBUG] Rate Limit Transport: Throttling request to %s for %s", req.URL.Host, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			r.Cancel()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return t.internal.RoundTrip(req)
+}
+
+// matchHost returns the value of host in hosts, or of a host pattern matching
+// it.
+func matchHost[V any](hosts map[string]V, host string) (V, bool) {
+	if v, ok := hosts[host]; ok {
+		return v, true
+	}
+	for pattern, v := range hosts {
+		if hostPatternMatches(pattern, host) {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// hostPatternMatches returns whether host is pattern with a location, a
+// single DNS label or part of one, in place of its placeholder, eg:
+// {{location}} or {{region}}.
+func hostPatternMatches(pattern, host string) bool {
+	prefix, rest, ok := strings.Cut(pattern, "{{")
+	if !ok {
+		return false
+	}
+	_, suffix, ok := strings.Cut(rest, "}}")
+	if !ok || len(host) <= len(prefix)+len(suffix) || !strings.HasPrefix(host, prefix) || !strings.HasSuffix(host, suffix) {
+		return false
+	}
+	location := host[len(prefix) : len(host)-len(suffix)]
+	return !strings.ContainsAny(location, ".:")
+}
@@ -0,0 +1,148 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse test server url: %v", err)
+	}
+
+	cases := map[string]struct {
+		limits     map[string]float64
+		minElapsed time.Duration
+		maxElapsed time.Duration
+	}{
+		"limited host": {
+			limits:     map[string]float64{u.Host: 10},
+			minElapsed: 400 * time.Millisecond,
+		},
+		"other host": {
+			limits:     map[string]float64{"compute.googleapis.com": 1},
+			maxElapsed: time.Second,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			client := ts.Client()
+			client.Transport = NewTransportWithRateLimits(http.DefaultTransport, tc.limits)
+
+			// The first 10 requests are sent at once, the next 5 are throttled.
+			start := time.Now()
+			for i := 0; i < 15; i++ {
+				resp, err := client.Get(ts.URL)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				resp.Body.Close()
+			}
+			elapsed := time.Since(start)
+			if elapsed < tc.minElapsed {
+				t.Errorf("expected requests to take at least %s, took %s", tc.minElapsed, elapsed)
+			}
+			if tc.maxElapsed > 0 && elapsed > tc.maxElapsed {
+				t.Errorf("expected requests to take at most %s, took %s", tc.maxElapsed, elapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransport_Retries(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			if _, err := w.Write([]byte("Code: 500")); err != nil {
+				t.Errorf("[ERROR] unable to write to response writer: %v", err)
+			}
+			return
+		}
+		w.WriteHeader(testRetryTransportCodeSuccess)
+	}))
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("unable to parse test server url: %v", err)
+	}
+
+	// Rate limits are applied inside retries, as set up by the provider
+	// configuration, so that every attempt is throttled.
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        NewTransportWithRateLimits(http.DefaultTransport, map[string]float64{u.Host: 1}),
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		retryPolicy:     &RetryPolicy{InitialBackoff: time.Millisecond * 10},
+	}
+
+	// The first attempt uses the only token, so the retry waits for the next
+	// one a second later.
+	start := time.Now()
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected the retried attempt to be throttled, took %s", elapsed)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestHostPatternMatches(t *testing.T) {
+	cases := map[string]struct {
+		pattern  string
+		host     string
+		expected bool
+	}{
+		"location prefix": {
+			pattern:  "{{location}}-run.googleapis.com",
+			host:     "us-central1-run.googleapis.com",
+			expected: true,
+		},
+		"location label": {
+			pattern:  "modelarmor.{{location}}.rep.googleapis.com",
+			host:     "modelarmor.us-central1.rep.googleapis.com",
+			expected: true,
+		},
+		"region": {
+			pattern:  "{{region}}-aiplatform.googleapis.com",
+			host:     "europe-west1-aiplatform.googleapis.com",
+			expected: true,
+		},
+		"global host": {
+			pattern: "{{location}}-run.googleapis.com",
+			host:    "run.googleapis.com",
+		},
+		"other host": {
+			pattern: "{{location}}-run.googleapis.com",
+			host:    "us-central1-aiplatform.googleapis.com",
+		},
+		"several labels": {
+			pattern: "{{location}}-run.googleapis.com",
+			host:    "evil.com.us-central1-run.googleapis.com",
+		},
+		"not a pattern": {
+			pattern: "compute.googleapis.com",
+			host:    "compute.googleapis.com",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := hostPatternMatches(tc.pattern, tc.host); got != tc.expected {
+				t.Errorf("expected hostPatternMatches(%q, %q) to be %v, got %v", tc.pattern, tc.host, tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"google.golang.org/api/googleapi"
+)
+
+const defaultRetryInitialBackoff = 500 * time.Millisecond
+
+// RetryPolicy controls the wait between attempts of requests retried by
+// retryTransport and Retry.
+type RetryPolicy struct {
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait computed from the backoff, not server hints.
+	// A zero value means no cap.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after each retry. A zero value uses a
+	// Fibonacci sequence: 0.5s, 1s, 1.5s, 2.5s, 4s, ...
+	Multiplier float64
+	// Jitter randomizes each wait by up to this fraction of it, between 0 and 1.
+	Jitter float64
+	// MaxAttempts stops retrying after this many attempts. A zero value only
+	// stops on the timeout.
+	MaxAttempts int
+	// HonorServerHints waits at least as long as requested by a Retry-After
+	// header or a google.rpc.RetryInfo error detail.
+	HonorServerHints bool
+}
+
+// DefaultRetryPolicy returns the policy used when the provider doesn't
+// configure a retry_policy block.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialBackoff: defaultRetryInitialBackoff,
+	}
+}
+
+// attemptsExhausted returns whether no attempt should follow the given number
+// of attempts. A nil policy doesn't limit attempts.
+func (p *RetryPolicy) attemptsExhausted(attempts int) bool {
+	return p != nil && p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// newBackoff returns the sequence of waits between the attempts of a request.
+func (p *RetryPolicy) newBackoff() *retryBackoff {
+	initial := p.InitialBackoff
+	if initial == 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	return &retryBackoff{
+		policy:  p,
+		current: initial,
+		next:    initial,
+	}
+}
+
+type retryBackoff struct {
+	policy  *RetryPolicy
+	current time.Duration
+	next    time.Duration
+}
+
+// Next returns the wait before the next attempt, given the server hint for
+// the last one, if any.
+func (b *retryBackoff) Next(hint time.Duration) time.Duration {
+	p := b.policy
+	wait := b.current
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Multiplier > 0 {
+		b.current = time.Duration(float64(b.current) * p.Multiplier)
+	} else {
+		last := b.current
+		b.current = b.current + b.next
+		b.next = last
+	}
+
+	if p.Jitter > 0 {
+		wait = wait + time.Duration((rand.Float64()*2-1)*p.Jitter*float64(wait))
+	}
+	if p.HonorServerHints && hint > wait {
+		wait = hint
+	}
+	return wait
+}
+
+// ServerRetryDelay returns the delay requested by the server for a failed
+// request, from the Retry-After header or a google.rpc.RetryInfo detail of
+// the googleapi.Error in err.
+func ServerRetryDelay(err error) (time.Duration, bool) {
+	gerr, ok := errwrap.GetType(err, &googleapi.Error{}).(*googleapi.Error)
+	if !ok {
+		return 0, false
+	}
+
+	for _, d := range gerr.Details {
+		detail, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _ := detail["@type"].(string); !strings.HasSuffix(t, "google.rpc.RetryInfo") {
+			continue
+		}
+		if v, ok := detail["retryDelay"].(string); ok {
+			if delay, err := time.ParseDuration(v); err == nil {
+				return delay, true
+			}
+		}
+	}
+
+	return retryAfterDelay(gerr.Header)
+}
+
+// retryAfterDelay parses a Retry-After header holding either seconds or an
+// HTTP date.
+func retryAfterDelay(header http.Header) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// responseRetryDelay returns the delay requested by the server in a failed
+// response, leaving its body readable.
+func responseRetryDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	respToCheck := *resp
+	if resp.Body != nil && resp.Body != http.NoBody {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return retryAfterDelay(resp.Header)
+		}
+		respToCheck.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return ServerRetryDelay(googleapi.CheckResponse(&respToCheck))
+}
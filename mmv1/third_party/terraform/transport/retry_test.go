@@ -1,11 +1,17 @@
 package transport
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"google.golang.org/api/googleapi"
 )
 
@@ -210,3 +216,308 @@ func TestRetryWithPolling_retriedAndFailed(t *testing.T) {
 		t.Errorf("expected error function to be called exactly twice, but was called %d times", retryCount)
 	}
 }
+
+func TestRetryWithEtag(t *testing.T) {
+	var sent []string
+	reads := 0
+	readEtag := func() (string, error) {
+		reads++
+		return fmt.Sprintf("etag-%d", reads), nil
+	}
+	send := func(etag string) error {
+		sent = append(sent, etag)
+		if len(sent) < 3 {
+			return &googleapi.Error{Code: 412}
+		}
+		return nil
+	}
+
+	if err := RetryWithEtag("etag-0", readEtag, send, 3, time.Minute); err != nil {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if expected := []string{"etag-0", "etag-1", "etag-2"}; !reflect.DeepEqual(sent, expected) {
+		t.Errorf("expected etags %v to be sent, got %v", expected, sent)
+	}
+}
+
+func TestRetryWithEtag_retriesExhausted(t *testing.T) {
+	i := 0
+	send := func(etag string) error {
+		i++
+		return &googleapi.Error{Code: 409}
+	}
+
+	err := RetryWithEtag("etag", func() (string, error) { return "etag", nil }, send, 1, time.Minute)
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 409 {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if i != 2 {
+		t.Errorf("expected send to be called twice, but was called %d times", i)
+	}
+}
+
+func TestRetryWithEtag_otherError(t *testing.T) {
+	i := 0
+	send := func(etag string) error {
+		i++
+		return &googleapi.Error{Code: 400}
+	}
+
+	if err := RetryWithEtag("etag", func() (string, error) { return "etag", nil }, send, 3, time.Minute); err == nil {
+		t.Errorf("unexpected nil error, expected an error")
+	}
+	if i != 1 {
+		t.Errorf("expected send to be called once, but was called %d times", i)
+	}
+}
+
+func TestRetry_policyMaxAttempts(t *testing.T) {
+	i := 0
+	f := func() error {
+		i++
+		return &googleapi.Error{
+			Code: 500,
+		}
+	}
+	err := Retry(RetryOptions{
+		RetryFunc: f,
+		Timeout:   time.Minute,
+		RetryPolicy: &RetryPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxAttempts:    3,
+		},
+	})
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 500 {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if i != 3 {
+		t.Errorf("expected error function to be called 3 times, but was called %d times", i)
+	}
+}
+
+func TestRetry_policyTimeout(t *testing.T) {
+	i := 0
+	f := func() error {
+		i++
+		return &googleapi.Error{
+			Code: 500,
+		}
+	}
+	err := Retry(RetryOptions{
+		RetryFunc: f,
+		Timeout:   100 * time.Millisecond,
+		RetryPolicy: &RetryPolicy{
+			InitialBackoff: time.Minute,
+		},
+	})
+	if _, ok := err.(*retry.TimeoutError); !ok {
+		t.Errorf("expected a timeout error, got: %v", err)
+	}
+	// The wait is cut short to make a last attempt at the deadline.
+	if i != 2 {
+		t.Errorf("expected error function to be called twice, but was called %d times", i)
+	}
+}
+
+func TestRetry_policyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	i := 0
+	f := func() error {
+		i++
+		cancel()
+		return &googleapi.Error{
+			Code: 500,
+		}
+	}
+	start := time.Now()
+	err := Retry(RetryOptions{
+		RetryFunc: f,
+		Timeout:   time.Minute,
+		RetryPolicy: &RetryPolicy{
+			InitialBackoff: time.Minute,
+		},
+		Context: ctx,
+	})
+	if err != context.Canceled {
+		t.Errorf("expected the retries to be canceled, got: %v", err)
+	}
+	if i != 1 {
+		t.Errorf("expected error function to be called once, but was called %d times", i)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the wait to stop when canceled, took %s", elapsed)
+	}
+}
+
+func TestRetry_defaultPolicy(t *testing.T) {
+	SetDefaultRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxAttempts:    2,
+	})
+	t.Cleanup(func() { SetDefaultRetryPolicy(nil) })
+
+	cases := map[string]RetryOptions{
+		"backoff": {
+			Timeout: time.Minute,
+		},
+		"polling": {
+			Timeout:      time.Minute,
+			PollInterval: time.Millisecond,
+		},
+	}
+	for tn, opt := range cases {
+		i := 0
+		opt.RetryFunc = func() error {
+			i++
+			return &googleapi.Error{
+				Code: 500,
+			}
+		}
+		err := Retry(opt)
+		if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != 500 {
+			t.Errorf("%s: unexpected error retrying: %v", tn, err)
+		}
+		if i != 2 {
+			t.Errorf("%s: expected error function to be called twice, but was called %d times", tn, i)
+		}
+	}
+}
+
+func TestSendRequest_policyAppliedOnce(t *testing.T) {
+	// Retry calls without a policy would use this one, and SendRequest must
+	// leave the retries to the client's transport instead.
+	SetDefaultRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxAttempts:    3,
+	})
+	t.Cleanup(func() { SetDefaultRetryPolicy(nil) })
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	transport := NewTransportWithDefaultRetries(http.DefaultTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond,
+		MaxAttempts:    3,
+	})
+	config := &Config{Client: &http.Client{Transport: transport}}
+
+	_, err := SendRequest(SendRequestOptions{
+		Config:    config,
+		Method:    "GET",
+		RawURL:    ts.URL,
+		UserAgent: "test",
+		Timeout:   time.Minute,
+	})
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != http.StatusServiceUnavailable {
+		t.Errorf("unexpected error sending request: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected the request to be sent 3 times, but was sent %d times", requests)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	cases := map[string]struct {
+		policy   RetryPolicy
+		hint     time.Duration
+		expected []time.Duration
+	}{
+		"fibonacci by default": {
+			policy:   RetryPolicy{InitialBackoff: 500 * time.Millisecond},
+			expected: []time.Duration{500 * time.Millisecond, time.Second, 1500 * time.Millisecond, 2500 * time.Millisecond, 4 * time.Second},
+		},
+		"multiplier": {
+			policy:   RetryPolicy{InitialBackoff: time.Second, Multiplier: 2},
+			expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		"max backoff": {
+			policy:   RetryPolicy{InitialBackoff: time.Second, Multiplier: 3, MaxBackoff: 5 * time.Second},
+			expected: []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		"server hint ignored": {
+			policy:   RetryPolicy{InitialBackoff: time.Second, Multiplier: 2},
+			hint:     time.Minute,
+			expected: []time.Duration{time.Second, 2 * time.Second},
+		},
+		"server hint honored": {
+			policy:   RetryPolicy{InitialBackoff: time.Second, Multiplier: 2, MaxBackoff: time.Second, HonorServerHints: true},
+			hint:     time.Minute,
+			expected: []time.Duration{time.Minute, time.Minute},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			backoff := tc.policy.newBackoff()
+			var waits []time.Duration
+			for range tc.expected {
+				waits = append(waits, backoff.Next(tc.hint))
+			}
+			if !reflect.DeepEqual(waits, tc.expected) {
+				t.Errorf("expected waits %v, got %v", tc.expected, waits)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff_jitter(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, Multiplier: 1, Jitter: 0.5}
+	backoff := policy.newBackoff()
+	for i := 0; i < 100; i++ {
+		if wait := backoff.Next(0); wait < 500*time.Millisecond || wait > 1500*time.Millisecond {
+			t.Fatalf("expected wait within 50%% of 1s, got %s", wait)
+		}
+	}
+}
+
+func TestServerRetryDelay(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected time.Duration
+		ok       bool
+	}{
+		"not a googleapi error": {
+			err: fmt.Errorf("error"),
+		},
+		"no hint": {
+			err: &googleapi.Error{Code: 429},
+		},
+		"retry after header": {
+			err: &googleapi.Error{
+				Code:   429,
+				Header: http.Header{"Retry-After": []string{"30"}},
+			},
+			expected: 30 * time.Second,
+			ok:       true,
+		},
+		"retry info detail": {
+			err: errwrap.Wrapf("nested error: {{err}}", &googleapi.Error{
+				Code: 429,
+				Details: []interface{}{
+					map[string]interface{}{
+						"@type":  "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "RATE_LIMIT_EXCEEDED",
+					},
+					map[string]interface{}{
+						"@type":      "type.googleapis.com/google.rpc.RetryInfo",
+						"retryDelay": "1.5s",
+					},
+				},
+			}),
+			expected: 1500 * time.Millisecond,
+			ok:       true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			delay, ok := ServerRetryDelay(tc.err)
+			if delay != tc.expected || ok != tc.ok {
+				t.Errorf("expected delay %s (%t), got %s (%t)", tc.expected, tc.ok, delay, ok)
+			}
+		})
+	}
+}
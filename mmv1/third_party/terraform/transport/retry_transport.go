@@ -71,8 +71,17 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 	return &copyT
 }
 
+// Returns a shallow copy of the retry transport waiting between attempts
+// according to the given policy. A nil policy uses DefaultRetryPolicy.
+func (t *retryTransport) WithRetryPolicy(policy *RetryPolicy) *retryTransport {
+	copyT := *t
+	copyT.retryPolicy = policy
+	return &copyT
+}
+
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
+	retryPolicy     *RetryPolicy
 	internal        http.RoundTripper
 }
 
@@ -93,9 +102,12 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 		}()
 	}
 
+	policy := t.retryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
 	attempts := 0
-	backoff := time.Millisecond * 500
-	nextBackoff := time.Millisecond * 500
+	backoff := policy.newBackoff()
 
 	// VCR depends on the original request body being consumed, so
 	// consume here. Since this won't affect the request itself,
@@ -105,6 +117,14 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 		log.Printf("[WARN] Retry Transport: Consuming original request body failed: %v", err)
 	}
 
+	// lastErr is the error of the last attempt, including error responses.
+	var lastErr error
+	ctx, span := StartSpan(ctx, "http.request", Attr("http.method", req.Method), Attr("http.host", req.URL.Host), Attr("http.path", req.URL.Path))
+	defer func() {
+		span.SetAttributes(Attr("http.attempts", attempts))
+		span.End(lastErr)
+	}()
+
 	log.Printf("[DEBUG] Retry Transport: starting RoundTrip retry loop")
 Retry:
 	for {
@@ -116,15 +136,30 @@ Retry:
 			log.Printf("[WARN] Retry Transport: Unable to copy request body: %v.", copyErr)
 			log.Printf("[WARN] Retry Transport: Running request as non-retryable")
 			resp, respErr = t.internal.RoundTrip(req)
+			lastErr = respErr
 			break Retry
 		}
 
 		log.Printf("[DEBUG] Retry Transport: request attempt %d", attempts)
+		newRequest = newRequest.WithContext(context.WithValue(newRequest.Context(), requestAttemptContextKey{}, attempts+1))
+		_, attemptSpan := StartSpan(ctx, "http.attempt", Attr("http.attempt", attempts+1))
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
 		resp, respErr = t.internal.RoundTrip(newRequest)
 		attempts++
 
-		retryErr := t.checkForRetryableError(resp, respErr)
+		retryErr, retryReason := t.checkForRetryableError(resp, respErr)
+		if resp != nil {
+			attemptSpan.SetAttributes(Attr("http.status_code", resp.StatusCode))
+		}
+		if retryErr != nil && retryErr.Retryable {
+			attemptSpan.SetAttributes(Attr("retry_reason", retryReason))
+		}
+		lastErr = nil
+		if retryErr != nil {
+			lastErr = retryErr.Err
+		}
+		attemptSpan.End(lastErr)
+
 		if retryErr == nil {
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request was successful")
 			break Retry
@@ -134,18 +169,23 @@ Retry:
 			break Retry
 		}
 
-		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
+		if policy.attemptsExhausted(attempts) {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, reached max attempts %d", policy.MaxAttempts)
+			break Retry
+		}
+
+		var hint time.Duration
+		if policy.HonorServerHints {
+			hint, _ = responseRetryDelay(resp)
+		}
+		wait := backoff.Next(hint)
+		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", wait)
 		select {
 		case <-ctx.Done():
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, context done: %v", ctx.Err())
 			break Retry
-		case <-time.After(backoff):
-			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
-
-			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
-			lastBackoff := backoff
-			backoff = backoff + nextBackoff
-			nextBackoff = lastBackoff
+		case <-time.After(wait):
+			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", wait)
 			continue
 		}
 	}
@@ -178,8 +218,9 @@ func copyHttpRequest(req *http.Request) (*http.Request, error) {
 
 // checkForRetryableError uses the googleapi.CheckResponse util to check for
 // errors in the response, and determines whether there is a retryable error.
-// in response/response error.
-func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr error) *retry.RetryError {
+// in response/response error. It also returns the reason a retryable error is
+// retried.
+func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr error) (*retry.RetryError, string) {
 	var errToCheck error
 
 	if respErr != nil {
@@ -194,7 +235,7 @@ func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr err
 			// error code and messages in the response body.
 			dumpBytes, err := httputil.DumpResponse(resp, true)
 			if err != nil {
-				return retry.NonRetryableError(fmt.Errorf("unable to check response for error: %v", err))
+				return retry.NonRetryableError(fmt.Errorf("unable to check response for error: %v", err)), ""
 			}
 			respToCheck.Body = ioutil.NopCloser(bytes.NewReader(dumpBytes))
 		}
@@ -202,10 +243,10 @@ func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr err
 	}
 
 	if errToCheck == nil {
-		return nil
+		return nil, ""
 	}
-	if IsRetryableError(errToCheck, t.retryPredicates, nil) {
-		return retry.RetryableError(errToCheck)
+	if isRetryable, reason := isRetryableErrorWithReason(errToCheck, t.retryPredicates, nil); isRetryable {
+		return retry.RetryableError(errToCheck), reason
 	}
-	return retry.NonRetryableError(errToCheck)
+	return retry.NonRetryableError(errToCheck), ""
 }
@@ -169,6 +169,55 @@ func TestRetryTransport_DoesNotRetryEmptyGetBody(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+func TestRetryTransport_MaxAttempts(t *testing.T) {
+	attempts := 0
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(testRetryTransportCodeRetry)
+			if _, err := w.Write([]byte(fmt.Sprintf("Code: %d", testRetryTransportCodeRetry))); err != nil {
+				t.Errorf("[ERROR] unable to write to response writer: %v", err)
+			}
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond * 10,
+		MaxAttempts:    3,
+	})
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailure(t, resp, err, testRetryTransportCodeRetry)
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(testRetryTransportCodeRetry)
+				return
+			}
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff:   time.Millisecond * 10,
+		HonorServerHints: true,
+	})
+
+	start := time.Now()
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for the Retry-After delay of 1s, waited %s", elapsed)
+	}
+}
+
 // handlers
 func testRetryTransportHandler_noRetries(t *testing.T, code int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -291,7 +340,7 @@ func testRetryTransport_checkBody(t *testing.T, resp *http.Response, expectedMsg
 
 	expectedBody := fmt.Sprintf("Request Body: %s", expectedMsg)
 	if !strings.HasSuffix(string(actualBody), expectedBody) {
-		t.Fatalf(expectedBody)
+		t.Fatal(expectedBody)
 	}
 }
 
@@ -1,7 +1,9 @@
 package transport
 
 import (
+	"context"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/errwrap"
@@ -14,22 +16,54 @@ type RetryOptions struct {
 	PollInterval         time.Duration
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// RetryPolicy controls the wait between attempts and their number. If nil,
+	// the policy set by SetDefaultRetryPolicy is used, and without one retries
+	// use retry.Retry's backoff. When PollInterval is set, only its MaxAttempts
+	// applies.
+	RetryPolicy *RetryPolicy
+	// Context cancels the retries. Defaults to context.Background().
+	Context context.Context
+
+	// retryTransportOwned is set when RetryFunc sends a request through the
+	// client's retryTransport, which already retries the default error retry
+	// predicates according to the retry policy. Retry then only retries the
+	// errors of ErrorRetryPredicates, without the policy, so that it doesn't
+	// multiply the attempts and waits of the transport.
+	retryTransportOwned bool
+}
+
+// defaultRetryPolicy is the policy of the Retry calls that don't set one.
+var defaultRetryPolicy atomic.Pointer[RetryPolicy]
+
+// SetDefaultRetryPolicy sets the policy used by Retry when RetryOptions don't
+// set a RetryPolicy. The provider sets it from its retry_policy block when it
+// is configured; a nil policy keeps retry.Retry's backoff.
+func SetDefaultRetryPolicy(policy *RetryPolicy) {
+	defaultRetryPolicy.Store(policy)
 }
 
 func Retry(opt RetryOptions) error {
 	if opt.Timeout == 0 {
 		opt.Timeout = 1 * time.Minute
 	}
+	if opt.Context == nil {
+		opt.Context = context.Background()
+	}
+	if opt.RetryPolicy == nil && !opt.retryTransportOwned {
+		opt.RetryPolicy = defaultRetryPolicy.Load()
+	}
 
 	if opt.PollInterval != 0 {
+		attempts := 0
 		refreshFunc := func() (interface{}, string, error) {
 			err := opt.RetryFunc()
 			if err == nil {
 				return "", "done", nil
 			}
 
-			// Check if it is a retryable error.
-			if IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates) {
+			// Check if it is a retryable error, with attempts left.
+			attempts++
+			if opt.isRetryable(err) && !opt.RetryPolicy.attemptsExhausted(attempts) {
 				return "", "retrying", nil
 			}
 
@@ -48,31 +82,100 @@ func Retry(opt RetryOptions) error {
 			PollInterval: opt.PollInterval,
 		}
 
-		_, err := stateChange.WaitForState()
+		_, err := stateChange.WaitForStateContext(opt.Context)
 		return err
 	}
 
-	return retry.Retry(opt.Timeout, func() *retry.RetryError {
+	if opt.RetryPolicy != nil {
+		return retryWithPolicy(opt)
+	}
+
+	return retry.RetryContext(opt.Context, opt.Timeout, func() *retry.RetryError {
 		err := opt.RetryFunc()
 		if err == nil {
 			return nil
 		}
-		if IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates) {
+		if opt.isRetryable(err) {
 			return retry.RetryableError(err)
 		}
 		return retry.NonRetryableError(err)
 	})
 }
 
-func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) bool {
-	if topErr == nil {
-		return false
+// retryWithPolicy calls opt.RetryFunc until it succeeds, fails with an error
+// that isn't retryable, runs out of attempts or opt.Timeout passes. Like
+// retry.RetryContext, the wait before the last attempt is cut short to make
+// it at the deadline.
+func retryWithPolicy(opt RetryOptions) error {
+	deadline := time.Now().Add(opt.Timeout)
+	backoff := opt.RetryPolicy.newBackoff()
+	for attempts := 1; ; attempts++ {
+		err := opt.RetryFunc()
+		if err == nil {
+			return nil
+		}
+		if !opt.isRetryable(err) {
+			return err
+		}
+		if opt.RetryPolicy.attemptsExhausted(attempts) {
+			log.Printf("[DEBUG] Stopping retries after %d attempts", attempts)
+			return err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return &retry.TimeoutError{
+				LastError: err,
+				Timeout:   opt.Timeout,
+			}
+		}
+		var hint time.Duration
+		if opt.RetryPolicy.HonorServerHints {
+			hint, _ = ServerRetryDelay(err)
+		}
+		wait := min(backoff.Next(hint), remaining)
+		log.Printf("[DEBUG] Waiting %s before retrying", wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-opt.Context.Done():
+			timer.Stop()
+			return opt.Context.Err()
+		case <-timer.C:
+		}
 	}
+}
 
+// isRetryable returns whether Retry should try again after err.
+func (opt RetryOptions) isRetryable(err error) bool {
+	if opt.retryTransportOwned {
+		isRetryable, _ := matchErrorPredicates(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates)
+		return isRetryable
+	}
+	return IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates)
+}
+
+func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) bool {
+	isRetryable, _ := isRetryableErrorWithReason(topErr, retryPredicates, abortPredicates)
+	return isRetryable
+}
+
+// isRetryableErrorWithReason is IsRetryableError, also returning the reason
+// given by the predicate that dismissed the error as retryable.
+func isRetryableErrorWithReason(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) (bool, string) {
 	retryPredicates = append(
 		// Global error retry predicates are registered in this default list.
 		defaultErrorRetryPredicates,
 		retryPredicates...)
+	return matchErrorPredicates(topErr, retryPredicates, abortPredicates)
+}
+
+// matchErrorPredicates returns whether topErr or an error it wraps matches one
+// of retryPredicates and none of abortPredicates, and the reason given by the
+// first matching retry predicate.
+func matchErrorPredicates(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) (bool, string) {
+	if topErr == nil {
+		return false, ""
+	}
 
 	// Check all wrapped errors for an abortable error status.
 	isAbortable := false
@@ -86,19 +189,23 @@ func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErro
 		}
 	})
 	if isAbortable {
-		return false
+		return false, ""
 	}
 
 	// Check all wrapped errors for a retryable error status.
 	isRetryable := false
+	reason := ""
 	errwrap.Walk(topErr, func(werr error) {
 		for _, pred := range retryPredicates {
 			if predRetry, predReason := pred(werr); predRetry {
 				log.Printf("[DEBUG] Dismissed an error as retryable. %s - %s", predReason, werr)
+				if !isRetryable {
+					reason = predReason
+				}
 				isRetryable = true
 				return
 			}
 		}
 	})
-	return isRetryable
+	return isRetryable, reason
 }
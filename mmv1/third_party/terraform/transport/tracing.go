@@ -0,0 +1,398 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// TraceFileEnvVar enables tracing to a local file, written as OTLP JSON
+	// lines, eg: for the otlpjsonfile receiver of the OpenTelemetry collector.
+	TraceFileEnvVar = "GOOGLE_TERRAFORM_TRACE_FILE"
+	// TraceEndpointEnvVar enables tracing to an OTLP/HTTP collector, eg:
+	// http://localhost:4318/v1/traces
+	TraceEndpointEnvVar = "GOOGLE_TERRAFORM_TRACE_ENDPOINT"
+)
+
+// Tracer records spans of the work done by the provider: resource CRUD
+// calls, HTTP attempts, operation polling and mutex waits. Spans started from
+// the context of another span are its children.
+//
+// Tracing is disabled by default. It's enabled by the environment variables
+// of ConfigureTracingFromEnv, or by another implementation, eg: one backed by
+// an OpenTelemetry SDK, passed to SetTracer.
+type Tracer interface {
+	StartSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is a timed unit of work started by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...SpanAttribute)
+	// End records the span, failed if err is not nil.
+	End(err error)
+}
+
+// SpanAttribute is a key/value pair describing a span. Values are strings,
+// ints or bools.
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+func Attr(key string, value interface{}) SpanAttribute {
+	return SpanAttribute{Key: key, Value: value}
+}
+
+var (
+	tracerMu       sync.RWMutex
+	tracer         Tracer
+	configureTrace sync.Once
+)
+
+// SetTracer sets the tracer of the provider. A nil tracer disables tracing.
+func SetTracer(t Tracer) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+	tracer = t
+}
+
+// TracingEnabled returns whether a tracer is set.
+func TracingEnabled() bool {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+	return tracer != nil
+}
+
+// StartSpan starts a span with the tracer of the provider, or a span
+// recording nothing if tracing is disabled. A nil ctx is allowed.
+func StartSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tracerMu.RLock()
+	t := tracer
+	tracerMu.RUnlock()
+	if t == nil {
+		return ctx, noopSpan{}
+	}
+	return t.StartSpan(ctx, name, attrs...)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...SpanAttribute) {}
+func (noopSpan) End(error)                      {}
+
+// ConfigureTracingFromEnv sets a tracer exporting spans to the file in
+// GOOGLE_TERRAFORM_TRACE_FILE and/or the collector at
+// GOOGLE_TERRAFORM_TRACE_ENDPOINT, if set. It only configures tracing once.
+func ConfigureTracingFromEnv() error {
+	var err error
+	configureTrace.Do(func() {
+		file := os.Getenv(TraceFileEnvVar)
+		endpoint := os.Getenv(TraceEndpointEnvVar)
+		if file == "" && endpoint == "" {
+			return
+		}
+
+		t := &otlpTracer{endpoint: endpoint}
+		if file != "" {
+			t.file, err = os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				err = fmt.Errorf("unable to open trace file %q: %s", file, err)
+				return
+			}
+		}
+		if endpoint != "" {
+			t.spans = make(chan otlpSpanData, 1000)
+			go t.exportToEndpoint()
+		}
+		log.Printf("[DEBUG] Tracing enabled, exporting spans to file %q and endpoint %q", file, endpoint)
+		SetTracer(t)
+	})
+	return err
+}
+
+// TraceResources returns copies of resources whose CRUD functions record each
+// call as a span, if tracing is enabled. The context functions are called with
+// the context of their span, and the others can get it with ResourceContext,
+// so that the requests and waits given it, eg: through
+// SendRequestOptions.Context, are traced as its children.
+func TraceResources(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	if !TracingEnabled() {
+		return resources
+	}
+	traced := make(map[string]*schema.Resource, len(resources))
+	for name, resource := range resources {
+		r := *resource
+		r.Create = traceCrudFunc(name, "create", r.Create)
+		r.Read = traceCrudFunc(name, "read", r.Read)
+		r.Update = traceCrudFunc(name, "update", r.Update)
+		r.Delete = traceCrudFunc(name, "delete", r.Delete)
+		r.CreateContext = traceCrudContextFunc(name, "create", r.CreateContext)
+		r.ReadContext = traceCrudContextFunc(name, "read", r.ReadContext)
+		r.UpdateContext = traceCrudContextFunc(name, "update", r.UpdateContext)
+		r.DeleteContext = traceCrudContextFunc(name, "delete", r.DeleteContext)
+		r.CreateWithoutTimeout = traceCrudContextFunc(name, "create", r.CreateWithoutTimeout)
+		r.ReadWithoutTimeout = traceCrudContextFunc(name, "read", r.ReadWithoutTimeout)
+		r.UpdateWithoutTimeout = traceCrudContextFunc(name, "update", r.UpdateWithoutTimeout)
+		r.DeleteWithoutTimeout = traceCrudContextFunc(name, "delete", r.DeleteWithoutTimeout)
+		traced[name] = &r
+	}
+	return traced
+}
+
+// resourceContexts holds the span context of the traced CRUD calls in
+// progress, by their *schema.ResourceData.
+var resourceContexts sync.Map
+
+// ResourceContext returns the context of the traced CRUD call in progress for
+// d, or context.Background() if there's none. It lets CRUD functions without
+// a context parameter, eg: generated ones, trace their requests and waits
+// under the span of the call.
+func ResourceContext(d *schema.ResourceData) context.Context {
+	if ctx, ok := resourceContexts.Load(d); ok {
+		return ctx.(context.Context)
+	}
+	return context.Background()
+}
+
+// withResourceContext makes ctx the context of the CRUD call for d while f
+// runs.
+func withResourceContext(ctx context.Context, d *schema.ResourceData, f func()) {
+	previous, nested := resourceContexts.Swap(d, ctx)
+	defer func() {
+		if nested {
+			resourceContexts.Store(d, previous)
+		} else {
+			resourceContexts.Delete(d)
+		}
+	}()
+	f()
+}
+
+func traceCrudFunc(resource, action string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		ctx, span := StartSpan(context.Background(), "resource."+action, Attr("resource", resource), Attr("id", d.Id()))
+		var err error
+		withResourceContext(ctx, d, func() { err = f(d, meta) })
+		span.End(err)
+		return err
+	}
+}
+
+func traceCrudContextFunc(resource, action string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, span := StartSpan(ctx, "resource."+action, Attr("resource", resource), Attr("id", d.Id()))
+		var diags diag.Diagnostics
+		withResourceContext(ctx, d, func() { diags = f(ctx, d, meta) })
+		var err error
+		for _, diagnostic := range diags {
+			if diagnostic.Severity == diag.Error {
+				err = fmt.Errorf("%s", diagnostic.Summary)
+				break
+			}
+		}
+		span.End(err)
+		return diags
+	}
+}
+
+// otlpTracer exports spans in the OTLP JSON format, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+type otlpTracer struct {
+	fileMu   sync.Mutex
+	file     *os.File
+	endpoint string
+	spans    chan otlpSpanData
+}
+
+type otlpSpanContextKey struct{}
+
+type otlpSpan struct {
+	tracer *otlpTracer
+	mu     sync.Mutex
+	data   otlpSpanData
+}
+
+// otlpSpanData is the OTLP JSON encoding of a span.
+type otlpSpanData struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+func (t *otlpTracer) StartSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	span := &otlpSpan{
+		tracer: t,
+		data: otlpSpanData{
+			SpanId:            randomHex(8),
+			Name:              name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		},
+	}
+	if parent, ok := ctx.Value(otlpSpanContextKey{}).(*otlpSpan); ok {
+		span.data.TraceId = parent.data.TraceId
+		span.data.ParentSpanId = parent.data.SpanId
+	} else {
+		span.data.TraceId = randomHex(16)
+	}
+	span.SetAttributes(attrs...)
+	return context.WithValue(ctx, otlpSpanContextKey{}, span), span
+}
+
+func (s *otlpSpan) SetAttributes(attrs ...SpanAttribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		var value map[string]interface{}
+		switch v := attr.Value.(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprintf("%v", v)}
+		}
+		s.data.Attributes = append(s.data.Attributes, otlpAttribute{Key: attr.Key, Value: value})
+	}
+}
+
+func (s *otlpSpan) End(err error) {
+	s.mu.Lock()
+	s.data.EndTimeUnixNano = strconv.FormatInt(time.Now().UnixNano(), 10)
+	s.data.Status = otlpStatus{Code: otlpStatusCodeOk}
+	if err != nil {
+		s.data.Status = otlpStatus{Code: otlpStatusCodeError, Message: err.Error()}
+	}
+	span := s.data
+	s.mu.Unlock()
+	s.tracer.export(span)
+}
+
+func (t *otlpTracer) export(span otlpSpanData) {
+	if t.file != nil {
+		line, err := json.Marshal(otlpTraces([]otlpSpanData{span}))
+		if err != nil {
+			log.Printf("[WARN] Unable to encode span %q: %s", span.Name, err)
+			return
+		}
+		t.fileMu.Lock()
+		defer t.fileMu.Unlock()
+		if _, err := t.file.Write(append(line, '\n')); err != nil {
+			log.Printf("[WARN] Unable to write span %q to trace file: %s", span.Name, err)
+		}
+	}
+	if t.spans != nil {
+		select {
+		case t.spans <- span:
+		default:
+			log.Printf("[WARN] Dropping span %q, the trace endpoint is too slow", span.Name)
+		}
+	}
+}
+
+// exportToEndpoint sends the spans ended since the last request to the
+// collector in a single request.
+func (t *otlpTracer) exportToEndpoint() {
+	for span := range t.spans {
+		spans := []otlpSpanData{span}
+	Drain:
+		for len(spans) < cap(t.spans) {
+			select {
+			case span := <-t.spans:
+				spans = append(spans, span)
+			default:
+				break Drain
+			}
+		}
+
+		body, err := json.Marshal(otlpTraces(spans))
+		if err != nil {
+			log.Printf("[WARN] Unable to encode spans: %s", err)
+			continue
+		}
+		resp, err := http.Post(t.endpoint, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("[WARN] Unable to send spans to trace endpoint: %s", err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("[WARN] Sending spans to trace endpoint failed with status %s", resp.Status)
+		}
+	}
+}
+
+// otlpTraces returns an OTLP ExportTraceServiceRequest holding spans.
+func otlpTraces(spans []otlpSpanData) map[string]interface{} {
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpAttribute{
+						{Key: "service.name", Value: map[string]interface{}{"stringValue": "terraform-provider-google"}},
+					},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "github.com/hashicorp/terraform-provider-google/google/transport"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("[WARN] Unable to generate random id: %s", err)
+	}
+	return hex.EncodeToString(b)
+}
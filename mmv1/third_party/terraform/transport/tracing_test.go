@@ -0,0 +1,409 @@
+package transport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...SpanAttribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *testSpan) End(err error) {
+	s.err = err
+	s.ended = true
+}
+
+// testTracer records the spans started while it's set, in order.
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &testSpan{name: name, attrs: make(map[string]interface{})}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (t *testTracer) spansNamed(name string) []*testSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var spans []*testSpan
+	for _, span := range t.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func setUpTestTracer(t *testing.T) *testTracer {
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	t.Cleanup(func() { SetTracer(nil) })
+	return tracer
+}
+
+func TestRetryTransport_Tracing(t *testing.T) {
+	tracer := setUpTestTracer(t)
+
+	attempts := 0
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			code := testRetryTransportCodeSuccess
+			if attempts == 1 {
+				code = testRetryTransportCodeRetry
+			}
+			w.WriteHeader(code)
+			if _, err := w.Write([]byte(fmt.Sprintf("Code: %d", code))); err != nil {
+				t.Errorf("[ERROR] unable to write to response writer: %v", err)
+			}
+		}))
+	defer ts.Close()
+	client.Transport = client.Transport.(*retryTransport).WithRetryPolicy(&RetryPolicy{
+		InitialBackoff: time.Millisecond * 10,
+	})
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+
+	requests := tracer.spansNamed("http.request")
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 http.request span, got %d", len(requests))
+	}
+	if !requests[0].ended || requests[0].err != nil || requests[0].attrs["http.attempts"] != 2 {
+		t.Errorf("expected successful http.request span with 2 attempts, got %+v", requests[0])
+	}
+
+	attemptSpans := tracer.spansNamed("http.attempt")
+	if len(attemptSpans) != 2 {
+		t.Fatalf("expected 2 http.attempt spans, got %d", len(attemptSpans))
+	}
+	first, second := attemptSpans[0], attemptSpans[1]
+	if first.err == nil || first.attrs["http.status_code"] != testRetryTransportCodeRetry || first.attrs["retry_reason"] == nil {
+		t.Errorf("expected first attempt to fail with a retry reason, got %+v", first)
+	}
+	if second.err != nil || second.attrs["http.status_code"] != testRetryTransportCodeSuccess || second.attrs["http.attempt"] != 2 {
+		t.Errorf("expected second attempt to succeed, got %+v", second)
+	}
+	if _, ok := second.attrs["retry_reason"]; ok {
+		t.Errorf("expected no retry reason for the second attempt, got %v", second.attrs["retry_reason"])
+	}
+}
+
+func TestPollingWaitTime_Tracing(t *testing.T) {
+	tracer := setUpTestTracer(t)
+
+	polls := 0
+	err := PollingWaitTime(
+		func() (map[string]interface{}, error) {
+			polls++
+			return map[string]interface{}{"polls": polls}, nil
+		},
+		func(resp map[string]interface{}, respErr error) PollResult {
+			if resp["polls"].(int) < 2 {
+				return PendingStatusPollResult("not ready")
+			}
+			return SuccessPollResult()
+		},
+		"Testing", time.Minute, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if waits := tracer.spansNamed("polling.wait"); len(waits) != 1 || waits[0].err != nil {
+		t.Errorf("expected 1 successful polling.wait span, got %+v", waits)
+	}
+	pollSpans := tracer.spansNamed("polling.poll")
+	if len(pollSpans) != 2 {
+		t.Fatalf("expected 2 polling.poll spans, got %d", len(pollSpans))
+	}
+	if pollSpans[0].attrs["retryable"] != true || pollSpans[1].err != nil {
+		t.Errorf("expected a pending then a successful poll, got %+v and %+v", pollSpans[0], pollSpans[1])
+	}
+}
+
+func TestMutexKV_Tracing(t *testing.T) {
+	tracer := setUpTestTracer(t)
+
+	mkv := NewMutexKV()
+	mkv.RLock("foo")
+	mkv.RUnlock("foo")
+	mkv.Lock("foo")
+	mkv.Unlock("foo")
+
+	waits := tracer.spansNamed("mutex.wait")
+	if len(waits) != 2 {
+		t.Fatalf("expected 2 mutex.wait spans, got %d", len(waits))
+	}
+	if waits[0].attrs["mode"] != "read" || waits[1].attrs["mode"] != "write" || waits[1].attrs["key"] != "foo" {
+		t.Errorf("unexpected mutex.wait spans: %+v and %+v", waits[0], waits[1])
+	}
+}
+
+func TestTraceResources(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return errors.New("create failed")
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+	resources := map[string]*schema.Resource{"google_test_resource": resource}
+
+	if traced := TraceResources(resources); traced["google_test_resource"] != resource {
+		t.Fatalf("expected resources to be unchanged when tracing is disabled")
+	}
+
+	tracer := setUpTestTracer(t)
+	traced := TraceResources(resources)["google_test_resource"]
+	if traced == resource {
+		t.Fatalf("expected a copy of the resource when tracing is enabled")
+	}
+	if traced.Update != nil || traced.CreateContext != nil {
+		t.Errorf("expected unset functions to stay unset")
+	}
+
+	d := traced.TestResourceData()
+	if err := traced.Create(d, nil); err == nil {
+		t.Errorf("expected create error")
+	}
+	if diags := traced.ReadContext(context.Background(), d, nil); diags.HasError() {
+		t.Errorf("unexpected read error: %v", diags)
+	}
+
+	creates := tracer.spansNamed("resource.create")
+	if len(creates) != 1 || creates[0].err == nil || creates[0].attrs["resource"] != "google_test_resource" {
+		t.Errorf("expected a failed resource.create span, got %+v", creates)
+	}
+	if reads := tracer.spansNamed("resource.read"); len(reads) != 1 || reads[0].err != nil {
+		t.Errorf("expected a successful resource.read span, got %+v", reads)
+	}
+}
+
+func TestTraceResources_Nesting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unable to create trace file: %s", err)
+	}
+	SetTracer(&otlpTracer{file: file})
+	t.Cleanup(func() { SetTracer(nil) })
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte("{}")); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+	config := &Config{Client: &http.Client{Transport: NewTransportWithDefaultRetries(http.DefaultTransport)}}
+
+	resource := TraceResources(map[string]*schema.Resource{
+		"google_test_resource": {
+			Schema: map[string]*schema.Schema{},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				_, err := SendRequest(SendRequestOptions{
+					Config:    meta.(*Config),
+					Method:    "GET",
+					RawURL:    ts.URL,
+					UserAgent: "test",
+					Context:   ctx,
+				})
+				return diag.FromErr(err)
+			},
+		},
+	})["google_test_resource"]
+	if diags := resource.ReadContext(context.Background(), resource.TestResourceData(), config); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	file.Close()
+
+	spans := make(map[string]otlpSpanData)
+	for _, span := range readOtlpSpans(t, path) {
+		spans[span.Name] = span
+	}
+	read, request, attempt := spans["resource.read"], spans["http.request"], spans["http.attempt"]
+	if read.SpanId == "" || read.ParentSpanId != "" {
+		t.Fatalf("expected a root resource.read span, got %+v", read)
+	}
+	if request.ParentSpanId != read.SpanId || request.TraceId != read.TraceId {
+		t.Errorf("expected http.request span to be a child of the resource.read span, got %+v", request)
+	}
+	if attempt.ParentSpanId != request.SpanId || attempt.TraceId != read.TraceId {
+		t.Errorf("expected http.attempt span to be a child of the http.request span, got %+v", attempt)
+	}
+}
+
+// A resource with the CRUD functions of generated resources, without a
+// context parameter, traces its requests, waits and locks under the span of
+// the call through ResourceContext.
+func TestTraceResources_NestingWithoutContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unable to create trace file: %s", err)
+	}
+	SetTracer(&otlpTracer{file: file})
+	t.Cleanup(func() { SetTracer(nil) })
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte("{}")); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+	config := &Config{Client: &http.Client{Transport: NewTransportWithDefaultRetries(http.DefaultTransport)}}
+
+	resource := TraceResources(map[string]*schema.Resource{
+		"google_test_resource": {
+			Schema: map[string]*schema.Schema{},
+			Create: func(d *schema.ResourceData, meta interface{}) error {
+				config := meta.(*Config)
+				MutexStore.LockContext(ResourceContext(d), "test-lock")
+				defer MutexStore.Unlock("test-lock")
+
+				_, err := SendRequest(SendRequestOptions{
+					Config:    config,
+					Context:   ResourceContext(d),
+					Method:    "POST",
+					RawURL:    ts.URL,
+					UserAgent: "test",
+				})
+				if err != nil {
+					return err
+				}
+				pollRead := func() (map[string]interface{}, error) {
+					return SendRequest(SendRequestOptions{
+						Config:    config,
+						Context:   ResourceContext(d),
+						Method:    "GET",
+						RawURL:    ts.URL,
+						UserAgent: "test",
+					})
+				}
+				return PollingWaitTimeContext(ResourceContext(d), pollRead, PollCheckForExistence, "Creating Test", time.Minute, 1)
+			},
+		},
+	})["google_test_resource"]
+	d := resource.TestResourceData()
+	if err := resource.Create(d, config); err != nil {
+		t.Fatalf("unexpected create error: %s", err)
+	}
+	file.Close()
+	if ResourceContext(d) != context.Background() {
+		t.Errorf("expected no resource context after the call")
+	}
+
+	spans := make(map[string][]otlpSpanData)
+	for _, span := range readOtlpSpans(t, path) {
+		spans[span.Name] = append(spans[span.Name], span)
+	}
+	if len(spans["resource.create"]) != 1 {
+		t.Fatalf("expected 1 resource.create span, got %+v", spans["resource.create"])
+	}
+	create := spans["resource.create"][0]
+	for _, name := range []string{"mutex.wait", "http.request", "polling.wait"} {
+		if len(spans[name]) == 0 {
+			t.Errorf("expected a %s span", name)
+		}
+		for _, span := range spans[name] {
+			if span.ParentSpanId != create.SpanId || span.TraceId != create.TraceId {
+				t.Errorf("expected %s span to be a child of the resource.create span, got %+v", name, span)
+			}
+		}
+	}
+	if requests := spans["http.request"]; len(requests) != 2 {
+		t.Errorf("expected the create and poll http.request spans, got %+v", requests)
+	}
+}
+
+func TestOtlpTracer_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unable to create trace file: %s", err)
+	}
+	tracer := &otlpTracer{file: file}
+
+	ctx, parent := tracer.StartSpan(context.Background(), "parent", Attr("string", "foo"), Attr("int", 1), Attr("bool", true))
+	_, child := tracer.StartSpan(ctx, "child")
+	child.End(errors.New("failed"))
+	parent.End(nil)
+	file.Close()
+
+	spans := readOtlpSpans(t, path)
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	childData, parentData := spans[0], spans[1]
+	if childData.TraceId != parentData.TraceId || childData.ParentSpanId != parentData.SpanId || parentData.ParentSpanId != "" {
+		t.Errorf("expected child span of parent, got %+v and %+v", childData, parentData)
+	}
+	if len(parentData.TraceId) != 32 || len(parentData.SpanId) != 16 {
+		t.Errorf("expected hex encoded 16 byte trace id and 8 byte span id, got %q and %q", parentData.TraceId, parentData.SpanId)
+	}
+	if childData.Status.Code != otlpStatusCodeError || childData.Status.Message != "failed" || parentData.Status.Code != otlpStatusCodeOk {
+		t.Errorf("unexpected span statuses: %+v and %+v", childData.Status, parentData.Status)
+	}
+	expectedAttrs := `[{"key":"string","value":{"stringValue":"foo"}},{"key":"int","value":{"intValue":"1"}},{"key":"bool","value":{"boolValue":true}}]`
+	if attrs, _ := json.Marshal(parentData.Attributes); string(attrs) != expectedAttrs {
+		t.Errorf("expected attributes %s, got %s", expectedAttrs, attrs)
+	}
+}
+
+// readOtlpSpans returns the spans of the OTLP JSON trace file at path.
+func readOtlpSpans(t *testing.T, path string) []otlpSpanData {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unable to open trace file: %s", err)
+	}
+	defer file.Close()
+
+	var spans []otlpSpanData
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var traces struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []otlpSpanData `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &traces); err != nil {
+			t.Fatalf("expected a line of OTLP JSON, got %q: %s", scanner.Text(), err)
+		}
+		spans = append(spans, traces.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}
+	return spans
+}
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -27,6 +28,10 @@ type SendRequestOptions struct {
 	Headers              http.Header
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// Context of the request, eg: the context of a CRUD function, which
+	// cancels it and holds the span it's traced under. Defaults to
+	// context.Background().
+	Context context.Context
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
@@ -57,6 +62,16 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		opt.Timeout = DefaultRequestTimeout
 	}
 
+	ctx := opt.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// The client's retryTransport retries the default retryable errors with
+	// the provider's retry policy until the deadline of the request, and the
+	// Retry below only retries the errors of the request's predicates.
+	reqCtx, cancel := context.WithTimeout(ctx, opt.Timeout)
+	defer cancel()
+
 	var res *http.Response
 	err := Retry(RetryOptions{
 		RetryFunc: func() error {
@@ -72,7 +87,7 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			if err != nil {
 				return err
 			}
-			req, err := http.NewRequest(opt.Method, u, &buf)
+			req, err := http.NewRequestWithContext(reqCtx, opt.Method, u, &buf)
 			if err != nil {
 				return err
 			}
@@ -93,6 +108,8 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		Timeout:              opt.Timeout,
 		ErrorRetryPredicates: opt.ErrorRetryPredicates,
 		ErrorAbortPredicates: opt.ErrorAbortPredicates,
+		Context:              ctx,
+		retryTransportOwned:  true,
 	})
 	if err != nil {
 		return nil, err